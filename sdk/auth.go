@@ -0,0 +1,178 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DEFAULT_API_KEY_ENV is the environment variable read by EnvKey when no name is provided
+const DEFAULT_API_KEY_ENV = "ONE_API_KEY"
+
+// ErrAuthRequired is returned without making a request when an endpoint requiring
+// authentication is called by a client without an API key.
+// It also matches ErrUnauthorized when used with errors.Is
+var ErrAuthRequired = fmt.Errorf("%w: endpoint requires an API key", ErrUnauthorized)
+
+// Authenticator provides the API key used to authorize requests
+type Authenticator interface {
+	// APIKey returns the key used to authorize a request, or an empty string if none is available
+	APIKey(ctx context.Context) (string, error)
+}
+
+// AuthenticatorFunc allows using an ordinary function as an Authenticator
+type AuthenticatorFunc func(ctx context.Context) (string, error)
+
+// APIKey calls f(ctx)
+func (f AuthenticatorFunc) APIKey(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+type staticKey string
+
+func (k staticKey) APIKey(ctx context.Context) (string, error) {
+	return string(k), nil
+}
+
+// StaticKey returns an Authenticator which always provides the same key
+func StaticKey(apiKey string) Authenticator {
+	return staticKey(apiKey)
+}
+
+type envKey string
+
+func (k envKey) APIKey(ctx context.Context) (string, error) {
+	return strings.TrimSpace(os.Getenv(string(k))), nil
+}
+
+// EnvKey returns an Authenticator which reads the key from the named environment variable
+// on each request. If name is empty, DEFAULT_API_KEY_ENV is used
+func EnvKey(name string) Authenticator {
+	if name == "" {
+		name = DEFAULT_API_KEY_ENV
+	}
+	return envKey(name)
+}
+
+type keyFile struct {
+	path string
+
+	mu      sync.Mutex
+	key     string
+	modTime time.Time
+}
+
+// KeyFile returns an Authenticator which reads the key from a file.
+// The file is read again whenever it is modified, allowing the key to be replaced without restarting
+func KeyFile(path string) Authenticator {
+	return &keyFile{path: path}
+}
+
+func (f *keyFile) APIKey(ctx context.Context) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if info.ModTime().Equal(f.modTime) {
+		return f.key, nil
+	}
+
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return "", err
+	}
+	f.key = strings.TrimSpace(string(data))
+	f.modTime = info.ModTime()
+	return f.key, nil
+}
+
+// RotatingKey is an Authenticator which obtains short-lived keys from a provider,
+// caching each key until shortly before it expires
+type RotatingKey struct {
+	fetch  func(ctx context.Context) (string, time.Time, error)
+	leeway time.Duration
+
+	mu      sync.Mutex
+	key     string
+	expires time.Time
+}
+
+// NewRotatingKey creates a RotatingKey using fetch to obtain a key and the time it expires.
+// A new key is fetched once the current key is within leeway of expiring
+func NewRotatingKey(fetch func(ctx context.Context) (key string, expires time.Time, err error), leeway time.Duration) *RotatingKey {
+	return &RotatingKey{fetch: fetch, leeway: leeway}
+}
+
+// APIKey returns the current key, fetching a new key if it is about to expire
+func (r *RotatingKey) APIKey(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.key != "" && time.Now().Add(r.leeway).Before(r.expires) {
+		return r.key, nil
+	}
+
+	key, expires, err := r.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	r.key, r.expires = key, expires
+	return key, nil
+}
+
+// Invalidate discards the current key so the next request fetches a new one,
+// such as after a key is revoked
+func (r *RotatingKey) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.key = ""
+}
+
+// endpointAuth describes whether each endpoint of The One API requires authentication.
+// Endpoints not listed are left for the API to decide
+var endpointAuth = []struct {
+	pattern  string
+	required bool
+}{
+	{"/book", false},
+	{"/book/{id}", false},
+	{"/book/{id}/chapter", false},
+	{"/movie", true},
+	{"/movie/{id}", true},
+	{"/movie/{id}/quote", true},
+	{"/character", true},
+	{"/character/{id}", true},
+	{"/character/{id}/quote", true},
+	{"/quote", true},
+	{"/quote/{id}", true},
+	{"/chapter", true},
+	{"/chapter/{id}", true},
+}
+
+// requiresAuth reports whether the endpoint at path is known to require authentication
+func requiresAuth(path string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, e := range endpointAuth {
+		pattern := strings.Split(strings.Trim(e.pattern, "/"), "/")
+		if len(pattern) != len(segments) {
+			continue
+		}
+		match := true
+		for i, p := range pattern {
+			if p != "{id}" && p != segments[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return e.required
+		}
+	}
+	return false
+}
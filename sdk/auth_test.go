@@ -0,0 +1,186 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthentication(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		mu      sync.Mutex
+		headers []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = append(headers, r.Header.Get("Authorization"))
+		mu.Unlock()
+		w.Write([]byte(`{"docs": []}`))
+	}))
+	defer server.Close()
+	lastHeader := func() (string, int) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(headers) == 0 {
+			return "", 0
+		}
+		return headers[len(headers)-1], len(headers)
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL})
+
+		_, err := client.Books().List()
+		assert.Nil(err)
+		header, n := lastHeader()
+		assert.Equal("", header)
+
+		_, err = client.Books().GetChapters(testID)
+		assert.Nil(err)
+
+		// authenticated endpoints fail without a round trip
+		_, err = client.Movies().List()
+		assert.ErrorIs(err, ErrAuthRequired)
+		assert.ErrorIs(err, ErrUnauthorized)
+		assert.ErrorIs(err, SDKError{Kind: ErrorKindRequest})
+		_, err = client.Characters().GetQuotes(testID)
+		assert.ErrorIs(err, ErrAuthRequired)
+
+		_, after := lastHeader()
+		assert.Equal(n+1, after)
+
+		// a key may still be provided per request
+		_, err = client.Movies().List(WithAPIKey("per-call"))
+		assert.Nil(err)
+		header, _ = lastHeader()
+		assert.Equal("Bearer per-call", header)
+	})
+
+	t.Run("static key applied to every resource", func(t *testing.T) {
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "static"})
+		for name, call := range resourceCalls(client, testID) {
+			call()
+			header, _ := lastHeader()
+			assert.Equal("Bearer static", header, name)
+		}
+	})
+
+	t.Run("empty key is never sent", func(t *testing.T) {
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, Authenticator: StaticKey("")})
+		_, err := client.Books().List(WithAPIKey(""))
+		assert.Nil(err)
+		header, _ := lastHeader()
+		assert.Equal("", header)
+
+		_, err = client.Quotes().List()
+		assert.ErrorIs(err, ErrAuthRequired)
+	})
+
+	t.Run("authenticator error", func(t *testing.T) {
+		failing := AuthenticatorFunc(func(ctx context.Context) (string, error) {
+			return "", errors.New("vault unavailable")
+		})
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, Authenticator: failing})
+		_, err := client.Books().List()
+		assert.ErrorIs(err, SDKError{Kind: ErrorKindRequest})
+		assert.Contains(err.Error(), "vault unavailable")
+	})
+}
+
+func TestAuthenticators(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv(DEFAULT_API_KEY_ENV, " from-env\n")
+		key, err := EnvKey("").APIKey(ctx)
+		assert.Nil(err)
+		assert.Equal("from-env", key)
+
+		t.Setenv("OTHER_KEY", "other")
+		key, err = EnvKey("OTHER_KEY").APIKey(ctx)
+		assert.Nil(err)
+		assert.Equal("other", key)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "key")
+		assert.Nil(ioutil.WriteFile(path, []byte("first\n"), 0600))
+
+		auth := KeyFile(path)
+		key, err := auth.APIKey(ctx)
+		assert.Nil(err)
+		assert.Equal("first", key)
+
+		assert.Nil(ioutil.WriteFile(path, []byte("second"), 0600))
+		later := time.Now().Add(time.Minute)
+		assert.Nil(os.Chtimes(path, later, later))
+		key, err = auth.APIKey(ctx)
+		assert.Nil(err)
+		assert.Equal("second", key)
+
+		_, err = KeyFile(filepath.Join(t.TempDir(), "missing")).APIKey(ctx)
+		assert.NotNil(err)
+	})
+
+	t.Run("rotating", func(t *testing.T) {
+		fetches := 0
+		expires := time.Now().Add(time.Hour)
+		auth := NewRotatingKey(func(ctx context.Context) (string, time.Time, error) {
+			fetches++
+			return "key", expires, nil
+		}, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			key, err := auth.APIKey(ctx)
+			assert.Nil(err)
+			assert.Equal("key", key)
+		}
+		assert.Equal(1, fetches)
+
+		auth.Invalidate()
+		auth.APIKey(ctx)
+		assert.Equal(2, fetches)
+
+		// keys within the leeway of expiring are refreshed
+		expires = time.Now().Add(30 * time.Second)
+		auth.Invalidate()
+		auth.APIKey(ctx)
+		auth.APIKey(ctx)
+		assert.Equal(4, fetches)
+	})
+}
+
+func TestRequiresAuth(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/book", false},
+		{"/book/" + testID, false},
+		{"/book/" + testID + "/chapter", false},
+		{"/movie", true},
+		{"/movie/" + testID + "/quote", true},
+		{"/character/" + testID, true},
+		{"/quote", true},
+		{"chapter/" + testID, true},
+		{"/race", false},
+		{"/book/" + testID + "/unknown", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, requiresAuth(tt.path))
+		})
+	}
+}
@@ -1,50 +1,36 @@
 package sdk
 
-import "fmt"
+import (
+	"context"
+	"encoding/json"
+)
 
-type booksResponse struct {
-	Docs []Book `json:"docs,omitempty"`
-}
+type booksResponse = listResponse[Book]
 
 // Book represents a single book
 type Book struct {
 	ID   string `json:"_id,omitempty"`
 	Name string `json:"name,omitempty"`
+	// Extra holds the fields returned by the API which are not part of the model
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // BooksClient provides methods for interacting with book resources
 type BooksClient struct {
-	c OneAPIClient
-}
-
-// List returns a list of all "Lord of the Rings" books
-func (b BooksClient) List(opts ...RequestOption) ([]Book, error) {
-	resp := booksResponse{}
-	err := b.c.doRequestInto("/book", &resp, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return resp.Docs, err
+	ResourceClient[Book]
 }
 
-// Get a book by it's ID
-func (b BooksClient) Get(id string, opts ...RequestOption) (Book, error) {
-	path := fmt.Sprintf("/book/%s", id)
-	resp := booksResponse{}
-	err := b.c.doRequestInto(path, &resp, opts...)
-	if err != nil {
-		return Book{}, err
-	}
-	return resp.Docs[0], err
+// Chapters returns the Collection of chapters of a specific book
+func (b BooksClient) Chapters(bookId string) Collection[Chapter] {
+	return Nested[Chapter](b.ResourceClient, bookId, "chapter")
 }
 
 // GetChapters returns all chapters of a specific book
 func (b BooksClient) GetChapters(bookId string, opts ...RequestOption) ([]Chapter, error) {
-	path := fmt.Sprintf("/book/%s/chapter", bookId)
-	resp := chapterResponse{}
-	err := b.c.doRequestInto(path, &resp, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return resp.Docs, err
+	return b.GetChaptersContext(context.Background(), bookId, opts...)
+}
+
+// GetChaptersContext is like GetChapters but uses the provided context for the request
+func (b BooksClient) GetChaptersContext(ctx context.Context, bookId string, opts ...RequestOption) ([]Chapter, error) {
+	return b.Chapters(bookId).ListContext(ctx, opts...)
 }
@@ -0,0 +1,260 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is matched by errors returned without making a request because the
+// client's CircuitBreaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned without making a request while the client's CircuitBreaker is open.
+// It matches ErrCircuitOpen when used with errors.Is
+type CircuitOpenError struct {
+	// Until is when the breaker will next allow a probe request
+	Until time.Time
+}
+
+func (e CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s until %s", ErrCircuitOpen, e.Until.Format(time.RFC3339))
+}
+
+// Is allows errors.Is to match ErrCircuitOpen
+func (e CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// BreakerState is the state of a CircuitBreaker
+type BreakerState int
+
+const (
+	// BreakerClosed allows all requests
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails all requests without sending them
+	BreakerOpen
+	// BreakerHalfOpen allows a limited number of probe requests to test whether the API has recovered
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// BreakerSettings configures when a CircuitBreaker trips and recovers.
+// Zero values fall back to those of DefaultBreakerSettings
+type BreakerSettings struct {
+	// ConsecutiveFailures trips the breaker after this many requests fail in a row
+	ConsecutiveFailures int
+	// FailureRate trips the breaker once this fraction of requests within Window fail,
+	// between 0 and 1. If zero, the breaker only trips on consecutive failures
+	FailureRate float64
+	// MinRequests is the number of requests within Window needed before FailureRate is considered
+	MinRequests int
+	// Window is the interval over which the failure rate is measured
+	Window time.Duration
+	// OpenTimeout is how long the breaker stays open before allowing probe requests
+	OpenTimeout time.Duration
+	// HalfOpenProbes is the number of probe requests allowed while half-open,
+	// all of which must succeed for the breaker to close
+	HalfOpenProbes int
+	// OnStateChange, if provided, is called on each transition between states
+	OnStateChange func(from, to BreakerState)
+}
+
+// DefaultBreakerSettings returns settings tripping the breaker after 5 consecutive failures,
+// probing the API again after 30 seconds
+func DefaultBreakerSettings() BreakerSettings {
+	return BreakerSettings{
+		ConsecutiveFailures: 5,
+		MinRequests:         10,
+		Window:              time.Minute,
+		OpenTimeout:         30 * time.Second,
+		HalfOpenProbes:      1,
+	}
+}
+
+// CircuitBreaker stops requests from being sent while the API is failing, returning a
+// CircuitOpenError instead of waiting for requests to time out.
+//
+// Network errors and server errors count as failures. Once the breaker trips it opens,
+// failing all requests until OpenTimeout has passed. It then half-opens, allowing probe requests
+// through which close the breaker if they succeed, or open it again if any fail.
+// A CircuitBreaker is safe for concurrent use and may be shared between clients
+type CircuitBreaker struct {
+	settings BreakerSettings
+
+	mu          sync.Mutex
+	state       BreakerState
+	consecutive int
+	// requests and failures within the window starting at windowStart
+	requests    int
+	failures    int
+	windowStart time.Time
+	openedAt    time.Time
+	// probes in flight and succeeded while half-open
+	probes    int
+	successes int
+	// generation is incremented on each transition
+	generation uint64
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker with the provided settings
+func NewCircuitBreaker(settings BreakerSettings) *CircuitBreaker {
+	defaults := DefaultBreakerSettings()
+	if settings.ConsecutiveFailures <= 0 {
+		settings.ConsecutiveFailures = defaults.ConsecutiveFailures
+	}
+	if settings.MinRequests <= 0 {
+		settings.MinRequests = defaults.MinRequests
+	}
+	if settings.Window <= 0 {
+		settings.Window = defaults.Window
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = defaults.OpenTimeout
+	}
+	if settings.HalfOpenProbes <= 0 {
+		settings.HalfOpenProbes = defaults.HalfOpenProbes
+	}
+	return &CircuitBreaker{settings: settings, windowStart: time.Now()}
+}
+
+// State returns the current state of the breaker
+func (b *CircuitBreaker) State() BreakerState {
+	if b == nil {
+		return BreakerClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && !time.Now().Before(b.openedAt.Add(b.settings.OpenTimeout)) {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// breakerTicket identifies a request allowed by a CircuitBreaker
+type breakerTicket struct {
+	// generation is the generation of the breaker's state when the request was allowed,
+	// so requests allowed before a transition do not affect the new state
+	generation uint64
+	probe      bool
+}
+
+// allow reports whether a request may be sent. Requests which are allowed must be followed by a call to done
+func (b *CircuitBreaker) allow() (breakerTicket, error) {
+	if b == nil {
+		return breakerTicket{}, nil
+	}
+	b.mu.Lock()
+	from := b.state
+	now := time.Now()
+	var err error
+	if b.state == BreakerOpen {
+		if until := b.openedAt.Add(b.settings.OpenTimeout); now.Before(until) {
+			err = CircuitOpenError{Until: until}
+		} else {
+			b.setState(BreakerHalfOpen)
+		}
+	}
+	ticket := breakerTicket{generation: b.generation}
+	if err == nil && b.state == BreakerHalfOpen {
+		if b.probes >= b.settings.HalfOpenProbes {
+			err = CircuitOpenError{Until: now.Add(b.settings.OpenTimeout)}
+		} else {
+			b.probes++
+			ticket.probe = true
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+	b.notify(from, to)
+	return ticket, err
+}
+
+// done records the outcome of a request allowed by allow
+func (b *CircuitBreaker) done(ticket breakerTicket, req *http.Request, resp *http.Response, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	from := b.state
+	if ticket.generation != b.generation {
+		b.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+	// requests canceled by the caller say nothing about the health of the API
+	ignored := err != nil && req.Context().Err() != nil
+
+	switch {
+	case ticket.probe:
+		b.probes--
+		switch {
+		case ignored:
+		case failed:
+			b.trip(now)
+		default:
+			b.successes++
+			if b.successes >= b.settings.HalfOpenProbes {
+				b.reset(now)
+			}
+		}
+	case ignored:
+	default:
+		if now.Sub(b.windowStart) > b.settings.Window {
+			b.requests, b.failures, b.windowStart = 0, 0, now
+		}
+		b.requests++
+		if failed {
+			b.failures++
+			b.consecutive++
+		} else {
+			b.consecutive = 0
+		}
+
+		rate := b.settings.FailureRate > 0 && b.requests >= b.settings.MinRequests &&
+			float64(b.failures)/float64(b.requests) >= b.settings.FailureRate
+		if b.consecutive >= b.settings.ConsecutiveFailures || rate {
+			b.trip(now)
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+	b.notify(from, to)
+}
+
+func (b *CircuitBreaker) trip(now time.Time) {
+	b.setState(BreakerOpen)
+	b.openedAt = now
+}
+
+func (b *CircuitBreaker) reset(now time.Time) {
+	b.setState(BreakerClosed)
+	b.consecutive, b.requests, b.failures, b.windowStart = 0, 0, 0, now
+}
+
+func (b *CircuitBreaker) setState(state BreakerState) {
+	b.state = state
+	b.probes, b.successes = 0, 0
+	b.generation++
+}
+
+// notify calls OnStateChange if the state changed, without holding the lock
+func (b *CircuitBreaker) notify(from, to BreakerState) {
+	if from != to && b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(from, to)
+	}
+}
@@ -0,0 +1,216 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type transition struct {
+	from, to BreakerState
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		failing  int32
+		requests int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch atomic.LoadInt32(&failing) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Write([]byte(`{"docs": []}`))
+		}
+	}))
+	defer server.Close()
+
+	newClient := func(settings BreakerSettings) (OneAPIClient, *CircuitBreaker, func() []transition) {
+		var (
+			mu          sync.Mutex
+			transitions []transition
+		)
+		settings.OnStateChange = func(from, to BreakerState) {
+			mu.Lock()
+			defer mu.Unlock()
+			transitions = append(transitions, transition{from, to})
+		}
+		breaker := NewCircuitBreaker(settings)
+		atomic.StoreInt32(&requests, 0)
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, CircuitBreaker: breaker})
+		return client, breaker, func() []transition {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]transition(nil), transitions...)
+		}
+	}
+
+	t.Run("consecutive failures", func(t *testing.T) {
+		client, breaker, transitions := newClient(BreakerSettings{ConsecutiveFailures: 3, OpenTimeout: 50 * time.Millisecond})
+
+		atomic.StoreInt32(&failing, 1)
+		for i := 0; i < 2; i++ {
+			_, err := client.Books().List()
+			assert.ErrorIs(err, ErrServer)
+		}
+		// a success resets the count
+		atomic.StoreInt32(&failing, 0)
+		_, err := client.Books().List()
+		assert.Nil(err)
+		assert.Equal(BreakerClosed, breaker.State())
+
+		atomic.StoreInt32(&failing, 1)
+		for i := 0; i < 3; i++ {
+			client.Books().List()
+		}
+		assert.Equal(BreakerOpen, breaker.State())
+
+		_, err = client.Books().List()
+		assert.ErrorIs(err, ErrCircuitOpen)
+		assert.ErrorIs(err, SDKError{Kind: ErrorKindHTTP})
+		var openErr CircuitOpenError
+		assert.True(errors.As(err, &openErr))
+		assert.WithinDuration(time.Now().Add(50*time.Millisecond), openErr.Until, 50*time.Millisecond)
+		assert.Equal(int32(6), atomic.LoadInt32(&requests))
+
+		// a failed probe opens the breaker again
+		time.Sleep(60 * time.Millisecond)
+		assert.Equal(BreakerHalfOpen, breaker.State())
+		_, err = client.Books().List()
+		assert.ErrorIs(err, ErrServer)
+		assert.Equal(BreakerOpen, breaker.State())
+
+		// a successful probe closes it
+		time.Sleep(60 * time.Millisecond)
+		atomic.StoreInt32(&failing, 0)
+		_, err = client.Books().List()
+		assert.Nil(err)
+		assert.Equal(BreakerClosed, breaker.State())
+
+		assert.Equal([]transition{
+			{BreakerClosed, BreakerOpen},
+			{BreakerOpen, BreakerHalfOpen},
+			{BreakerHalfOpen, BreakerOpen},
+			{BreakerOpen, BreakerHalfOpen},
+			{BreakerHalfOpen, BreakerClosed},
+		}, transitions())
+	})
+
+	t.Run("failure rate", func(t *testing.T) {
+		client, breaker, _ := newClient(BreakerSettings{ConsecutiveFailures: 100, FailureRate: 0.5, MinRequests: 4})
+		for i := 0; i < 4; i++ {
+			atomic.StoreInt32(&failing, int32(i%2))
+			client.Books().List()
+			if i < 3 {
+				assert.Equal(BreakerClosed, breaker.State(), i)
+			}
+		}
+		assert.Equal(BreakerOpen, breaker.State())
+	})
+
+	t.Run("client errors are not failures", func(t *testing.T) {
+		client, breaker, _ := newClient(BreakerSettings{ConsecutiveFailures: 1})
+		atomic.StoreInt32(&failing, 2)
+		_, err := client.Books().List()
+		assert.ErrorIs(err, ErrNotFound)
+		assert.Equal(BreakerClosed, breaker.State())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = client.Books().ListContext(ctx)
+		assert.ErrorIs(err, context.Canceled)
+		assert.Equal(BreakerClosed, breaker.State())
+	})
+
+	t.Run("retries stop once open", func(t *testing.T) {
+		breaker := NewCircuitBreaker(BreakerSettings{ConsecutiveFailures: 2})
+		atomic.StoreInt32(&requests, 0)
+		client := NewWithConfig(ClientConfig{
+			BaseURL:        server.URL,
+			CircuitBreaker: breaker,
+			Retry:          &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond},
+		})
+		atomic.StoreInt32(&failing, 1)
+		_, err := client.Books().List()
+		assert.ErrorIs(err, ErrCircuitOpen)
+		assert.Equal(int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		breaker := NewCircuitBreaker(BreakerSettings{ConsecutiveFailures: 1, OpenTimeout: time.Minute})
+		limiter := NewRateLimiter(1, time.Minute)
+		atomic.StoreInt32(&requests, 0)
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, CircuitBreaker: breaker, RateLimiter: limiter})
+		atomic.StoreInt32(&failing, 1)
+		_, err := client.Books().List()
+		assert.ErrorIs(err, ErrServer)
+
+		// requests fail fast without waiting for the limiter, which has no tokens left
+		for i := 0; i < 3; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			start := time.Now()
+			_, err = client.Books().ListContext(ctx)
+			cancel()
+			assert.ErrorIs(err, ErrCircuitOpen)
+			assert.Less(time.Since(start), 100*time.Millisecond)
+		}
+		assert.Equal(int32(1), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("concurrent probes", func(t *testing.T) {
+		var inFlight int32
+		release := make(chan struct{})
+		blocking := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("fail") != "" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			atomic.AddInt32(&inFlight, 1)
+			<-release
+			w.Write([]byte(`{"docs": []}`))
+		}))
+		defer blocking.Close()
+
+		breaker := NewCircuitBreaker(BreakerSettings{ConsecutiveFailures: 1, OpenTimeout: 20 * time.Millisecond, HalfOpenProbes: 2})
+		client := NewWithConfig(ClientConfig{BaseURL: blocking.URL, CircuitBreaker: breaker})
+		client.Books().List(WithFilterMatch("fail", "1"))
+		assert.Equal(BreakerOpen, breaker.State())
+		time.Sleep(30 * time.Millisecond)
+
+		var (
+			wg   sync.WaitGroup
+			open int32
+		)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.Books().List(); errors.Is(err, ErrCircuitOpen) {
+					atomic.AddInt32(&open, 1)
+				}
+			}()
+		}
+
+		// only two probes are sent, the other requests fail fast
+		assert.Eventually(func() bool {
+			return atomic.LoadInt32(&open) == 3 && atomic.LoadInt32(&inFlight) == 2
+		}, time.Second, time.Millisecond)
+		assert.Equal(BreakerHalfOpen, breaker.State())
+
+		close(release)
+		wg.Wait()
+		assert.Equal(BreakerClosed, breaker.State())
+	})
+}
@@ -0,0 +1,289 @@
+package sdk
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCacheTTL is how long responses are cached when a CachePolicy does not provide a TTL
+const DefaultCacheTTL = 24 * time.Hour
+
+// Cache stores the bodies of API responses.
+// Implementations must be safe for concurrent use
+type Cache interface {
+	// Get returns the value stored for key, if present and not expired
+	Get(key string) ([]byte, bool)
+	// Set stores value for key, expiring after ttl
+	Set(key string, value []byte, ttl time.Duration)
+	// Delete removes any value stored for key
+	Delete(key string)
+}
+
+// CachePolicy configures how responses are cached by the client.
+//
+// The data served by The One API rarely changes, so caching responses avoids repeated requests
+// counting against the rate limit. Responses are keyed by their endpoint including the query,
+// and only successful responses are cached.
+//
+//	cache := &sdk.CachePolicy{
+//		Store:       sdk.NewLRUCache(1000),
+//		ResourceTTL: map[string]time.Duration{"quote": time.Hour},
+//	}
+//	client := sdk.NewWithConfig(sdk.ClientConfig{Cache: cache})
+type CachePolicy struct {
+	// hits and misses are accessed atomically, and kept first for alignment
+	hits   int64
+	misses int64
+
+	// Store holds the cached responses
+	Store Cache
+	// TTL is how long responses are cached, if zero DefaultCacheTTL is used
+	TTL time.Duration
+	// ResourceTTL overrides TTL for a resource, such as "quote".
+	// A TTL of zero or less disables caching of the resource
+	ResourceTTL map[string]time.Duration
+	// OnLookup, if provided, is called with the key of each request and whether it was served from the cache
+	OnLookup func(key string, hit bool)
+}
+
+// CacheStats counts the requests served by a CachePolicy
+type CacheStats struct {
+	// Hits is the number of requests served from the cache
+	Hits int64
+	// Misses is the number of requests sent to the API after not being found in the cache
+	Misses int64
+}
+
+// Stats returns the number of cache hits and misses
+func (p *CachePolicy) Stats() CacheStats {
+	if p == nil {
+		return CacheStats{}
+	}
+	return CacheStats{Hits: atomic.LoadInt64(&p.hits), Misses: atomic.LoadInt64(&p.misses)}
+}
+
+// ttl returns how long to cache responses from the endpoint at path
+func (p *CachePolicy) ttl(path string) time.Duration {
+	if ttl, ok := p.ResourceTTL[resourceOf(path)]; ok {
+		return ttl
+	}
+	if p.TTL <= 0 {
+		return DefaultCacheTTL
+	}
+	return p.TTL
+}
+
+// enabled reports whether responses from the endpoint at path are cached
+func (p *CachePolicy) enabled(path string) bool {
+	return p != nil && p.Store != nil && p.ttl(path) > 0
+}
+
+// lookup returns the cached response for key, recording whether it was found
+func (p *CachePolicy) lookup(key string) ([]byte, bool) {
+	data, ok := p.Store.Get(key)
+	if ok {
+		atomic.AddInt64(&p.hits, 1)
+	} else {
+		atomic.AddInt64(&p.misses, 1)
+	}
+	if p.OnLookup != nil {
+		p.OnLookup(key, ok)
+	}
+	return data, ok
+}
+
+// resourceOf returns the resource listed by the endpoint at path,
+// such as quote for both /quote/{id} and /character/{id}/quote
+func resourceOf(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) == 3 {
+		return segments[2]
+	}
+	return segments[0]
+}
+
+// cacheMode controls how a single request uses the cache
+type cacheMode int
+
+const (
+	cacheDefault cacheMode = iota
+	// cacheBypass neither reads nor writes the cache
+	cacheBypass
+	// cacheRefresh skips reading the cache but stores the response
+	cacheRefresh
+)
+
+// WithCacheBypass causes the request to be sent to the API without reading or updating the cache
+func WithCacheBypass() RequestOption {
+	return func(req *RequestBuilder) {
+		req.cache = cacheBypass
+	}
+}
+
+// WithCacheRefresh causes the request to be sent to the API, replacing any cached response
+func WithCacheRefresh() RequestOption {
+	return func(req *RequestBuilder) {
+		req.cache = cacheRefresh
+	}
+}
+
+// LRUCache is an in-memory Cache holding a limited number of responses,
+// evicting the least recently used once full
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRUCache creates an LRUCache holding up to size responses
+func NewLRUCache(size int) *LRUCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUCache{
+		size:    size,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// Get returns the value stored for key, if present and not expired
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*lruEntry)
+	if time.Now().After(entry.expires) {
+		c.remove(el)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+// Set stores value for key, evicting the least recently used value if the cache is full
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &lruEntry{key: key, value: value, expires: time.Now().Add(ttl)}
+	if el, ok := c.entries[key]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Delete removes any value stored for key
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+}
+
+// Len returns the number of values stored, including any which have expired but not yet been removed
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRUCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*lruEntry).key)
+}
+
+// DiskCache is a Cache storing each response as a file within a directory,
+// allowing responses to be reused across runs
+type DiskCache struct {
+	dir string
+}
+
+type diskEntry struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires"`
+	Value   []byte    `json:"value"`
+}
+
+// NewDiskCache creates a DiskCache storing responses in dir, which is created if it does not exist
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// Get returns the value stored for key, if present and not expired
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	data, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return nil, false
+	}
+	if time.Now().After(entry.Expires) {
+		c.Delete(key)
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+// Set stores value for key. Failures to write are ignored, causing the next request to miss the cache
+func (c *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	data, err := json.Marshal(diskEntry{Key: key, Expires: time.Now().Add(ttl), Value: value})
+	if err != nil {
+		return
+	}
+
+	// write to a temporary file first so readers never see a partial entry
+	tmp, err := ioutil.TempFile(c.dir, ".tmp-")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// Delete removes any value stored for key
+func (c *DiskCache) Delete(key string) {
+	os.Remove(c.path(key))
+}
@@ -0,0 +1,182 @@
+package sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCache(t *testing.T) {
+	assert := assert.New(t)
+	cache := NewLRUCache(2)
+
+	cache.Set("a", []byte("1"), time.Hour)
+	cache.Set("b", []byte("2"), time.Hour)
+	_, ok := cache.Get("a")
+	assert.True(ok)
+
+	// b is least recently used
+	cache.Set("c", []byte("3"), time.Hour)
+	_, ok = cache.Get("b")
+	assert.False(ok)
+	val, ok := cache.Get("a")
+	assert.True(ok)
+	assert.Equal([]byte("1"), val)
+	assert.Equal(2, cache.Len())
+
+	cache.Set("a", []byte("4"), time.Hour)
+	val, _ = cache.Get("a")
+	assert.Equal([]byte("4"), val)
+
+	cache.Set("expired", []byte("5"), -time.Second)
+	_, ok = cache.Get("expired")
+	assert.False(ok)
+
+	cache.Delete("a")
+	_, ok = cache.Get("a")
+	assert.False(ok)
+}
+
+func TestDiskCache(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir)
+	assert.Nil(err)
+
+	cache.Set("https://the-one-api.dev/v2/book", []byte(`{"docs": []}`), time.Hour)
+	cache.Set("expired", []byte("old"), -time.Second)
+
+	// entries are read by another instance using the same directory
+	reopened, err := NewDiskCache(dir)
+	assert.Nil(err)
+	val, ok := reopened.Get("https://the-one-api.dev/v2/book")
+	assert.True(ok)
+	assert.Equal([]byte(`{"docs": []}`), val)
+
+	_, ok = reopened.Get("expired")
+	assert.False(ok)
+	_, ok = reopened.Get("missing")
+	assert.False(ok)
+
+	reopened.Delete("https://the-one-api.dev/v2/book")
+	_, ok = cache.Get("https://the-one-api.dev/v2/book")
+	assert.False(ok)
+}
+
+func TestCachePolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"docs": [{"_id": "5cd99d4bde30eff6ebccfe9e", "name": "Frodo Baggins"}], "total": 1}`))
+	}))
+	defer server.Close()
+
+	newClient := func(policy *CachePolicy) OneAPIClient {
+		atomic.StoreInt32(&requests, 0)
+		return NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key", Cache: policy})
+	}
+
+	t.Run("repeated requests are served from the cache", func(t *testing.T) {
+		var lookups []bool
+		policy := &CachePolicy{
+			Store:    NewLRUCache(10),
+			OnLookup: func(key string, hit bool) { lookups = append(lookups, hit) },
+		}
+		client := newClient(policy)
+
+		for i := 0; i < 3; i++ {
+			characters, err := client.Characters().List(WithLimit(1))
+			assert.Nil(err)
+			assert.Equal("Frodo Baggins", characters[0].Name)
+		}
+		character, err := client.Characters().Get(testID)
+		assert.Nil(err)
+		assert.Equal("Frodo Baggins", character.Name)
+
+		// a different query is a different key
+		_, err = client.Characters().List(WithLimit(2))
+		assert.Nil(err)
+
+		assert.Equal(int32(3), atomic.LoadInt32(&requests))
+		assert.Equal(CacheStats{Hits: 2, Misses: 3}, policy.Stats())
+		assert.Equal([]bool{false, true, true, false, false}, lookups)
+	})
+
+	t.Run("bypass and refresh", func(t *testing.T) {
+		policy := &CachePolicy{Store: NewLRUCache(10)}
+		client := newClient(policy)
+
+		client.Characters().List()
+		client.Characters().List(WithCacheBypass())
+		assert.Equal(int32(2), atomic.LoadInt32(&requests))
+		assert.Equal(CacheStats{Misses: 1}, policy.Stats())
+
+		client.Characters().List(WithCacheRefresh())
+		client.Characters().List()
+		assert.Equal(int32(3), atomic.LoadInt32(&requests))
+		assert.Equal(CacheStats{Hits: 1, Misses: 1}, policy.Stats())
+
+		// bypassed responses are not stored
+		client.Characters().List(WithCacheBypass(), WithLimit(5))
+		client.Characters().List(WithLimit(5))
+		assert.Equal(int32(5), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("resource TTLs", func(t *testing.T) {
+		policy := &CachePolicy{
+			Store:       NewLRUCache(10),
+			TTL:         time.Hour,
+			ResourceTTL: map[string]time.Duration{"quote": 0, "character": -time.Second},
+		}
+		assert.Equal(time.Hour, policy.ttl("/movie"))
+		assert.Equal(time.Duration(0), policy.ttl("/character/"+testID+"/quote"))
+		assert.Equal(time.Hour, policy.ttl("/movie/"+testID))
+
+		client := newClient(policy)
+		client.Quotes().List()
+		client.Quotes().List()
+		client.Movies().Quotes(testID).List()
+		client.Movies().Quotes(testID).List()
+		client.Characters().List()
+		client.Characters().List()
+		assert.Equal(int32(6), atomic.LoadInt32(&requests))
+		assert.Equal(CacheStats{}, policy.Stats())
+
+		client.Movies().List()
+		client.Movies().List()
+		assert.Equal(int32(7), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		client := newClient(&CachePolicy{Store: NewLRUCache(10)})
+		_, err := client.Characters().List(WithFilterMatch("fail", "1"))
+		assert.ErrorIs(err, ErrServer)
+		_, err = client.Characters().List(WithFilterMatch("fail", "1"))
+		assert.ErrorIs(err, ErrServer)
+		assert.Equal(int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("disk", func(t *testing.T) {
+		store, err := NewDiskCache(t.TempDir())
+		assert.Nil(err)
+		client := newClient(&CachePolicy{Store: store})
+		client.Books().List()
+
+		// a new client reuses the stored responses
+		client = NewWithConfig(ClientConfig{BaseURL: server.URL, Cache: &CachePolicy{Store: store}})
+		books, err := client.Books().List()
+		assert.Nil(err)
+		assert.Len(books, 1)
+		assert.Equal(int32(1), atomic.LoadInt32(&requests))
+	})
+}
@@ -1,16 +1,17 @@
 package sdk
 
 import (
-	"errors"
-	"fmt"
+	"context"
+	"encoding/json"
 )
 
-type characterResponse struct {
-	paginatedResponse
-	Docs []Character
-}
+type characterResponse = listResponse[Character]
 
-// Character represents a single character
+// Character represents a single character.
+//
+// Birth, Death and Height hold the text returned by the API, which is "NaN" or empty when unknown.
+// Birth and Death are parsed by BirthDate and DeathDate. Height is only available as text, since the API
+// writes it in mixed units and forms such as "1.98m", "6'6\"" or "Tall" which cannot be reliably converted
 type Character struct {
 	ID      string `json:"_id"`
 	Birth   string `json:"birth"`
@@ -21,51 +22,52 @@ type Character struct {
 	Spouse  string `json:"spouse"`
 	Name    string `json:"name"`
 	Race    string `json:"race"`
+	Hair    string `json:"hair"`
 	WikiUrl string `json:"wikiUrl"`
+	// Extra holds the fields returned by the API which are not part of the model
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-// CharactersClient provides methods for interacting with character resources
-type CharactersClient struct {
-	c OneAPIClient
+// BirthDate returns the character's birth parsed from the text returned by the API, such as "TA 2931"
+func (c Character) BirthDate() MiddleEarthDate {
+	return ParseMiddleEarthDate(c.Birth)
 }
 
-// List returns a list of all characters
-func (ch CharactersClient) List(opts ...RequestOption) ([]Character, error) {
-	resp := characterResponse{}
+// DeathDate returns the character's death parsed from the text returned by the API
+func (c Character) DeathDate() MiddleEarthDate {
+	return ParseMiddleEarthDate(c.Death)
+}
 
-	opts = ch.c.appendOptsToAuth(opts...)
-	err := ch.c.doRequestInto("/character", &resp, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return resp.Docs, err
+// Lifespan returns the number of years the character lived, reporting false if
+// their birth or death is not known to the year
+func (c Character) Lifespan() (int, bool) {
+	return Lifespan(c.BirthDate(), c.DeathDate())
 }
 
-// Get returns a single Character by ID
-func (ch CharactersClient) Get(id string, opts ...RequestOption) (Character, error) {
-	path := fmt.Sprintf("/character/%s", id)
-	resp := characterResponse{}
+// CharactersClient provides methods for interacting with character resources
+type CharactersClient struct {
+	ResourceClient[Character]
+}
 
-	opts = ch.c.appendOptsToAuth(opts...)
-	err := ch.c.doRequestInto(path, &resp, opts...)
-	if err != nil {
-		return Character{}, err
-	}
-	return resp.Docs[0], err
+// Quotes returns the Collection of quotes spoken by a single Character
+func (ch CharactersClient) Quotes(id string) Collection[Quote] {
+	return Nested[Quote](ch.ResourceClient, id, "quote")
 }
 
 // GetQuotes returns a all quotes of a single Character by ID
 func (ch CharactersClient) GetQuotes(id string, opts ...RequestOption) ([]Quote, error) {
-	path := fmt.Sprintf("/character/%s/quote", id)
-	resp := quoteResponse{}
+	return ch.GetQuotesContext(context.Background(), id, opts...)
+}
 
-	opts = ch.c.appendOptsToAuth(opts...)
-	err := ch.c.doRequestInto(path, &resp, opts...)
+// GetQuotesContext is like GetQuotes but uses the provided context for the request
+func (ch CharactersClient) GetQuotesContext(ctx context.Context, id string, opts ...RequestOption) ([]Quote, error) {
+	col := ch.Quotes(id)
+	quotes, err := col.ListContext(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
-	if len(resp.Docs) == 0 {
-		return nil, errors.New("no quotes available")
+	if len(quotes) == 0 {
+		return nil, noQuotesError(col.path)
 	}
-	return resp.Docs, err
+	return quotes, nil
 }
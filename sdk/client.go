@@ -1,13 +1,13 @@
 package sdk
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 )
 
 const DEFAULT_BASE_URL = "https://the-one-api.dev/v2"
@@ -15,9 +15,21 @@ const DEFAULT_BASE_URL = "https://the-one-api.dev/v2"
 // OneAPIClient is the sdk's interface to The One API
 type OneAPIClient struct {
 	client         *http.Client
-	apiKey         string
+	auth           Authenticator
 	baseURL        string
 	persistentOpts []RequestOption
+	retry          *RetryPolicy
+	limiter        *RateLimiter
+	cache          *CachePolicy
+	validators     ValidatorStore
+	middleware     []Middleware
+	logger         Logger
+	logBodies      bool
+	tracing        Tracer
+	metrics        Meter
+	breaker        *CircuitBreaker
+	flights        *flightGroup
+	strict         bool
 }
 
 // ClientConfig provides config to override client behavior
@@ -28,10 +40,59 @@ type ClientConfig struct {
 	BaseURL string
 	// APIKey required for authenticated endpoints
 	ApiKey string
+	// Authenticator provides the API key for each request, such as from an environment variable or file
+	// if provided, ApiKey is ignored
+	Authenticator Authenticator
 
 	// Request Options to apply to all requests
-	// note any options provided to methods will overwrite any duplicates
+	// note pagination and sorting options provided to methods will overwrite those set here,
+	// while filters are applied in addition to them
 	PersistentOptions []RequestOption
+
+	// Retry configures how failed requests are retried
+	// if nil, requests are attempted only once
+	Retry *RetryPolicy
+
+	// RateLimiter limits the rate of requests sent to the API
+	// if nil, requests are not limited by the client
+	RateLimiter *RateLimiter
+
+	// Cache configures how responses are cached
+	// if nil, every request is sent to the API
+	Cache *CachePolicy
+
+	// Validators stores the ETag and Last-Modified headers of responses, which are sent with
+	// later requests to the same endpoint so unchanged responses are not downloaded again
+	// if nil, conditional requests are not made
+	Validators ValidatorStore
+
+	// Middleware wraps every request sent to the API, with the first being the outermost
+	Middleware []Middleware
+
+	// Logger receives structured events for each request, such as a *slog.Logger
+	// if nil, nothing is logged
+	Logger Logger
+	// LogBodies includes the beginning of each response body in debug level events
+	LogBodies bool
+
+	// Tracer starts a span around each call to a resource, such as quotes.list
+	// if nil, calls are not traced
+	Tracer Tracer
+	// Meter records metrics for the requests sent to the API
+	// if nil, metrics are not recorded
+	Meter Meter
+
+	// CircuitBreaker stops requests from being sent while the API is failing
+	// if nil, requests are always sent
+	CircuitBreaker *CircuitBreaker
+
+	// CoalesceRequests sends identical requests made concurrently, those with the same method,
+	// endpoint, query and authorization, as a single request whose response is shared by each caller
+	CoalesceRequests bool
+
+	// StrictDecoding fails decoding responses containing fields missing from the models with an
+	// error matching ErrUnknownField, to notice changes to the API. Otherwise they are kept in each model's Extra field
+	StrictDecoding bool
 }
 
 // NewUnAuthenticated creates a new client without authorization
@@ -48,7 +109,7 @@ func New(apiKey string) OneAPIClient {
 	return OneAPIClient{
 		client:         http.DefaultClient,
 		baseURL:        DEFAULT_BASE_URL,
-		apiKey:         apiKey,
+		auth:           StaticKey(apiKey),
 		persistentOpts: []RequestOption{},
 	}
 }
@@ -61,6 +122,9 @@ func NewWithConfig(config ClientConfig) OneAPIClient {
 	} else {
 		c = New(config.ApiKey)
 	}
+	if config.Authenticator != nil {
+		c.auth = config.Authenticator
+	}
 	if config.Client != nil {
 		c.client = config.Client
 	}
@@ -70,6 +134,20 @@ func NewWithConfig(config ClientConfig) OneAPIClient {
 	if len(config.PersistentOptions) > 0 {
 		c.persistentOpts = config.PersistentOptions
 	}
+	c.retry = config.Retry
+	c.limiter = config.RateLimiter
+	c.cache = config.Cache
+	c.validators = config.Validators
+	c.middleware = config.Middleware
+	c.logger = config.Logger
+	c.logBodies = config.LogBodies
+	c.tracing = config.Tracer
+	c.metrics = config.Meter
+	c.breaker = config.CircuitBreaker
+	c.strict = config.StrictDecoding
+	if config.CoalesceRequests {
+		c.flights = newFlightGroup()
+	}
 	return c
 }
 
@@ -79,19 +157,25 @@ func (c OneAPIClient) buildEndpoint(path string) string {
 
 }
 
-func (c OneAPIClient) appendOptsToAuth(opts ...RequestOption) []RequestOption {
-	auth := []RequestOption{WithAPIKey(c.apiKey)}
-	return append(auth, opts...)
-
-}
-
-func (c OneAPIClient) doRequest(path string, opts ...RequestOption) (*http.Response, error) {
+func (c OneAPIClient) newRequest(ctx context.Context, path string, opts ...RequestOption) (*RequestBuilder, error) {
 	endpoint := c.buildEndpoint(path)
 
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
+	req := &RequestBuilder{Request: httpReq}
+
+	// authorize the request before applying options,
+	// allowing WithAPIKey to override the client's key
+	if c.auth != nil {
+		apiKey, err := c.auth.APIKey(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("obtaining API key: %w", err)
+		}
+		WithAPIKey(apiKey)(req)
+	}
+
 	// apply persistent opts first
 	for _, f := range c.persistentOpts {
 		f(req)
@@ -101,65 +185,184 @@ func (c OneAPIClient) doRequest(path string, opts ...RequestOption) (*http.Respo
 	for _, f := range opts {
 		f(req)
 	}
-
-	return c.client.Do(req)
+	if err := req.Err(); err != nil {
+		return nil, err
+	}
+	if len(req.expand) > 0 {
+		return nil, fmt.Errorf("%w: WithExpand is only supported by the ListExpanded and GetExpanded methods", ErrInvalidQuery)
+	}
+	req.encodeQuery()
+	return req, nil
 }
 
-func (c OneAPIClient) doRequestInto(path string, v interface{}, opts ...RequestOption) error {
-	resp, err := c.doRequest(path, opts...)
+func (c OneAPIClient) doRequestInto(ctx context.Context, path string, v interface{}, opts ...RequestOption) error {
+	var (
+		resp      *http.Response
+		attempts  int
+		received  int
+		coalesced bool
+		log       = c.log()
+		span      = spanFromContext(ctx)
+		start     = time.Now()
+	)
+	fail := func(kind ErrorKind, err error) error {
+		sdkErr := SDKError{Kind: kind, Method: http.MethodGet, Endpoint: path, Err: err, Attempts: attempts}
+		if resp != nil {
+			sdkErr.StatusCode = resp.StatusCode
+		}
+		msg := "request failed"
+		if kind == ErrorKindDeserialization {
+			msg = "response decode failed"
+		}
+		log.Error(msg, "method", sdkErr.Method, "endpoint", path, "kind", kind.String(), "status", sdkErr.StatusCode,
+			"attempts", attempts, "latency", time.Since(start), "error", err)
+		span.SetAttributes(Attribute{"http.status_code", sdkErr.StatusCode}, Attribute{"oneapi.attempts", attempts})
+		span.RecordError(sdkErr)
+		c.recordRequest(ctx, path, sdkErr.StatusCode, sent(attempts, coalesced), time.Since(start), received, kind)
+		return sdkErr
+	}
+
+	req, err := c.newRequest(ctx, path, opts...)
 	if err != nil {
-		return SDKError{"HTTP Error", path, err}
+		return fail(ErrorKindRequest, err)
+	}
+	if requiresAuth(path) && req.Header.Get("Authorization") == "" {
+		return fail(ErrorKindRequest, ErrAuthRequired)
+	}
+
+	log.Debug("request started", "method", req.Method, "endpoint", path, "url", req.URL.String(), "headers", redactHeaders(req.Header))
+
+	useCache := c.cache.enabled(path) && req.cache != cacheBypass
+	key := req.URL.String()
+	if useCache && req.cache != cacheRefresh {
+		data, ok := c.cache.lookup(key)
+		log.Debug("cache lookup", "endpoint", path, "key", key, "hit", ok)
+		span.SetAttributes(Attribute{"oneapi.cache_hit", ok})
+		if ok {
+			if err := c.decode(data, v); err != nil {
+				return fail(ErrorKindDeserialization, err)
+			}
+			return nil
+		}
 	}
 
-	var bodyCopy bytes.Buffer
-	r := io.TeeReader(resp.Body, &bodyCopy)
+	var res response
+	fetch := func(ctx context.Context) response {
+		return c.fetch(req.Request.WithContext(ctx), key, req.cache != cacheBypass)
+	}
+	if c.flights != nil {
+		var shared bool
+		res, shared = c.flights.do(ctx, flightKey(req), fetch)
+		if shared {
+			log.Debug("request coalesced", "endpoint", path, "url", req.URL.String())
+			span.SetAttributes(Attribute{"oneapi.coalesced", true})
+		}
+		// only the caller sending the request counts its attempts in metrics
+		coalesced = shared
+	} else {
+		res = fetch(ctx)
+	}
+	resp, attempts, received = res.resp, res.attempts, len(res.data)
+	if res.err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fail(ErrorKindHTTP, ctxErr)
+		}
+		if res.readErr {
+			return fail(ErrorKindRead, res.err)
+		}
+		return fail(ErrorKindHTTP, res.err)
+	}
 
-	// use TeeReader to allow reading into
-	// response and error structs
-	// TODO: maybe simplify this, and be more efficient
+	// the whole body is read to allow decoding into
+	// both response and error structs
+	// TODO: maybe be more efficient
 	// by allowing error info in response structs
 
 	apiErr := APIError{}
-
-	data, err := ioutil.ReadAll(r)
-	if err != nil {
-		return SDKError{"Error reading response", path, err}
+	data, notModified := res.data, res.notModified
+	if c.logBodies {
+		log.Debug("response body", "endpoint", path, "status", resp.StatusCode, "body", loggedBody(data))
+	}
+	if !notModified && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+		return fail(statusError(path, resp, data))
 	}
 
 	// check for error
 	if err := json.Unmarshal(data, &apiErr); err == nil && apiErr.Message != "" {
-		return SDKError{"API Error", path, apiErr}
+		return fail(ErrorKindAPI, apiErr)
 	}
 
 	// now unmarshal into provided struct
-	err = json.Unmarshal(bodyCopy.Bytes(), v)
+	err = c.decode(data, v)
 	if err != nil {
-		return SDKError{"Deserialization Error", path, err}
+		return fail(ErrorKindDeserialization, err)
+	}
+	if !notModified && req.cache != cacheBypass {
+		storeValidators(c.validators, key, resp, data)
 	}
+	if useCache {
+		c.cache.Store.Set(key, data, c.cache.ttl(path))
+	}
+	log.Info("request completed", "method", req.Method, "endpoint", path, "status", resp.StatusCode,
+		"attempts", attempts, "latency", time.Since(start), "bytes", len(data), "notModified", notModified)
+	span.SetAttributes(Attribute{"http.status_code", resp.StatusCode}, Attribute{"oneapi.attempts", attempts})
+	c.recordRequest(ctx, path, resp.StatusCode, sent(attempts, coalesced), time.Since(start), received, ErrorKindUnknown)
 	return nil
 }
 
+// fetch sends req and reads its response. If conditional, the request is made conditional
+// on the validators stored for key, with a 304 response replaced by the stored body
+func (c OneAPIClient) fetch(req *http.Request, key string, conditional bool) response {
+	var notModifiedBody []byte
+	if conditional {
+		notModifiedBody, conditional = setConditional(c.validators, key, req)
+	}
+
+	resp, attempts, err := c.do(req)
+	res := response{resp: resp, attempts: attempts, err: err}
+	if err != nil {
+		return res
+	}
+	defer resp.Body.Close()
+
+	res.data, res.err = ioutil.ReadAll(resp.Body)
+	res.readErr = res.err != nil
+	if res.err == nil && conditional && resp.StatusCode == http.StatusNotModified {
+		res.data, res.notModified = notModifiedBody, true
+	}
+	return res
+}
+
+// sent returns the number of attempts to record in metrics, which is none for requests coalesced
+// into a request sent by another caller
+func sent(attempts int, coalesced bool) int {
+	if coalesced {
+		return 0
+	}
+	return attempts
+}
+
 // Books provides access to the /book namespace of resources
 func (c OneAPIClient) Books() BooksClient {
-	return BooksClient{c: c}
+	return BooksClient{NewResourceClient[Book](c, "book")}
 }
 
 // Books provides access to the /movie namespace of resources
 func (c OneAPIClient) Movies() MoviesClient {
-	return MoviesClient{c: c}
+	return MoviesClient{NewResourceClient[Movie](c, "movie")}
 }
 
 // Characters provides access to the /character namespace of resources
 func (c OneAPIClient) Characters() CharactersClient {
-	return CharactersClient{c: c}
+	return CharactersClient{NewResourceClient[Character](c, "character")}
 }
 
 // Characters provides access to the /quote namespace of resources
 func (c OneAPIClient) Quotes() QuotesClient {
-	return QuotesClient{c: c}
+	return QuotesClient{NewResourceClient[Quote](c, "quote")}
 }
 
 // Characters provides access to the /chapter namespace of resources
 func (c OneAPIClient) Chapters() ChapterClient {
-	return ChapterClient{c: c}
+	return ChapterClient{NewResourceClient[Chapter](c, "chapter")}
 }
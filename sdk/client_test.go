@@ -1,7 +1,9 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -26,16 +28,16 @@ func TestNewWithConfig(t *testing.T) {
 		config ClientConfig
 		want   OneAPIClient
 	}{
-		{"with client", confWithClient, OneAPIClient{baseURL: DEFAULT_BASE_URL, apiKey: "", client: httpClient}},
-		{"with apiKey", confWithAPIKey, OneAPIClient{baseURL: DEFAULT_BASE_URL, apiKey: "123", client: http.DefaultClient}},
-		{"with apiKey", configWithBaseURL, OneAPIClient{baseURL: "https://example.com", apiKey: "", client: http.DefaultClient}},
+		{"with client", confWithClient, OneAPIClient{baseURL: DEFAULT_BASE_URL, auth: nil, client: httpClient}},
+		{"with apiKey", confWithAPIKey, OneAPIClient{baseURL: DEFAULT_BASE_URL, auth: StaticKey("123"), client: http.DefaultClient}},
+		{"with apiKey", configWithBaseURL, OneAPIClient{baseURL: "https://example.com", auth: nil, client: http.DefaultClient}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got := NewWithConfig(tt.config)
 			assert.Equal(t, got.client.Timeout, tt.want.client.Timeout)
 			assert.Equal(t, got.baseURL, tt.want.baseURL)
-			assert.Equal(t, got.apiKey, tt.want.apiKey)
+			assert.Equal(t, got.auth, tt.want.auth)
 		})
 	}
 }
@@ -67,7 +69,7 @@ func TestOneAPIClient_buildEndpoint(t *testing.T) {
 	}
 }
 
-func TestOneAPIClient_doRequest(t *testing.T) {
+func TestOneAPIClient_fetch(t *testing.T) {
 	assert := assert.New(t)
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/error" {
@@ -123,28 +125,27 @@ func TestOneAPIClient_doRequest(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := client.doRequest(tt.args.path, tt.args.opts...)
+			req, err := client.newRequest(context.Background(), tt.args.path, tt.args.opts...)
+			assert.Nil(err)
+			got := client.fetch(req.Request, req.URL.String(), false)
 			if tt.wantErr {
 				expectedErr := APIError{Success: false, Message: "sample error message"}
 
-				data, err := io.ReadAll(got.Body)
-				assert.Nil(err)
-
 				gotErr := APIError{}
-				err = json.Unmarshal(data, &gotErr)
+				err = json.Unmarshal(got.data, &gotErr)
 				assert.Nil(err)
 
 				assert.Equal(expectedErr, gotErr)
 
 				return
 			}
-			assert.Nil(err)
-			assert.Contains(got.Request.URL.String(), tt.args.path)
+			assert.Nil(got.err)
+			assert.Contains(got.resp.Request.URL.String(), tt.args.path)
 			// assert.Equal(tt.args.path, got.Request.URL.Path)
-			fmt.Println(got.Request.URL.String())
+			fmt.Println(got.resp.Request.URL.String())
 
 			for k, v := range tt.wantHeader {
-				assert.Equal(v, got.Header.Get(k))
+				assert.Equal(v, got.resp.Header.Get(k))
 			}
 
 		})
@@ -157,7 +158,7 @@ func TestOneAPIClient_doRequestInto(t *testing.T) {
 
 	apiErr := APIError{Success: false, Message: "sample error message"}
 
-	wantErrResp := SDKError{message: "API Error", endpoint: "/error", err: apiErr}
+	wantErrResp := SDKError{Kind: ErrorKindAPI, Method: http.MethodGet, Endpoint: "/error", StatusCode: http.StatusOK, Err: apiErr, Attempts: 1}
 	wantBookResp := booksResponse{Docs: []Book{{ID: "123", Name: "Sample Book"}}}
 	wantMovieResp := moviesResponse{Docs: []Movie{{ID: "123", Name: "Sample Movie", RuntimeInMinutes: 122}}}
 	wantChapterResp := chapterResponse{Docs: []Chapter{{ID: "123", Name: "Sample Chapter", Book: "smaple book"}}}
@@ -188,7 +189,7 @@ func TestOneAPIClient_doRequestInto(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewWithConfig(ClientConfig{BaseURL: server.URL})
+	client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key"})
 
 	type args struct {
 		path string
@@ -213,32 +214,32 @@ func TestOneAPIClient_doRequestInto(t *testing.T) {
 			var err error
 			switch tt.args.path {
 			case "/error":
-				err = client.doRequestInto(tt.args.path, make(map[string]string))
+				err = client.doRequestInto(context.Background(), tt.args.path, make(map[string]string))
 				assert.NotNil(err)
 				assert.ErrorIs(err, wantErrResp)
 			case "/book":
 				v := booksResponse{}
-				err = client.doRequestInto(tt.args.path, &v)
+				err = client.doRequestInto(context.Background(), tt.args.path, &v)
 				assert.Nil(err)
 				assert.Equal(wantBookResp, v)
 			case "/movie":
 				v := moviesResponse{}
-				err = client.doRequestInto(tt.args.path, &v)
+				err = client.doRequestInto(context.Background(), tt.args.path, &v)
 				assert.Nil(err)
 				assert.Equal(wantMovieResp, v)
 			case "/chapter":
 				v := chapterResponse{}
-				err = client.doRequestInto(tt.args.path, &v)
+				err = client.doRequestInto(context.Background(), tt.args.path, &v)
 				assert.Nil(err)
 				assert.Equal(wantChapterResp, v)
 			case "/character":
 				v := characterResponse{}
-				err = client.doRequestInto(tt.args.path, &v)
+				err = client.doRequestInto(context.Background(), tt.args.path, &v)
 				assert.Nil(err)
 				assert.Equal(wantCharacterResp, v)
 			case "/quote":
 				v := quoteResponse{}
-				err = client.doRequestInto(tt.args.path, &v)
+				err = client.doRequestInto(context.Background(), tt.args.path, &v)
 				assert.Nil(err)
 				assert.Equal(wantQuoteResp, v)
 			}
@@ -246,3 +247,84 @@ func TestOneAPIClient_doRequestInto(t *testing.T) {
 		})
 	}
 }
+
+func TestOneAPIClient_doRequestInto_context(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key"})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := client.Books().ListContext(ctx)
+		assert.ErrorIs(err, context.Canceled)
+
+		var sdkErr SDKError
+		assert.True(errors.As(err, &sdkErr))
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := client.Characters().GetContext(ctx, "5cd99d4bde30eff6ebccfe9e")
+		assert.ErrorIs(err, context.DeadlineExceeded)
+
+		var sdkErr SDKError
+		assert.True(errors.As(err, &sdkErr))
+	})
+}
+
+func TestListPage(t *testing.T) {
+	assert := assert.New(t)
+	meta := paginatedResponse{Total: 933, Limit: 2, Offset: 0, Page: 3, Pages: 467}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp interface{}
+		switch r.URL.Path {
+		case "/book":
+			resp = booksResponse{meta, []Book{{ID: "1"}, {ID: "2"}}}
+		case "/movie":
+			resp = moviesResponse{meta, []Movie{{ID: "1"}, {ID: "2"}}}
+		case "/character":
+			resp = characterResponse{meta, []Character{{ID: "1"}, {ID: "2"}}}
+		case "/quote":
+			resp = quoteResponse{meta, []Quote{{ID: "1"}, {ID: "2"}}}
+		case "/chapter":
+			resp = chapterResponse{meta, []Chapter{{ID: "1"}, {ID: "2"}}}
+		}
+		data, err := json.Marshal(resp)
+		assert.Nil(err)
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key"})
+	assertMeta := func(total, limit, offset, page, pages int, hasNext bool, err error) {
+		assert.Nil(err)
+		assert.Equal(meta, paginatedResponse{total, limit, offset, page, pages})
+		assert.True(hasNext)
+	}
+
+	books, err := client.Books().ListPage(WithLimit(2), WithPage(3))
+	assertMeta(books.Total, books.Limit, books.Offset, books.Page, books.Pages, books.HasNext(), err)
+	assert.Equal([]Book{{ID: "1"}, {ID: "2"}}, books.Items)
+
+	movies, err := client.Movies().ListPage()
+	assertMeta(movies.Total, movies.Limit, movies.Offset, movies.Page, movies.Pages, movies.HasNext(), err)
+	assert.Len(movies.Items, 2)
+
+	characters, err := client.Characters().ListPage()
+	assertMeta(characters.Total, characters.Limit, characters.Offset, characters.Page, characters.Pages, characters.HasNext(), err)
+	assert.Len(characters.Items, 2)
+
+	quotes, err := client.Quotes().ListPage()
+	assertMeta(quotes.Total, quotes.Limit, quotes.Offset, quotes.Page, quotes.Pages, quotes.HasNext(), err)
+	assert.Len(quotes.Items, 2)
+
+	chapters, err := client.Chapters().ListPage()
+	assertMeta(chapters.Total, chapters.Limit, chapters.Offset, chapters.Page, chapters.Pages, chapters.HasNext(), err)
+	assert.Len(chapters.Items, 2)
+}
@@ -0,0 +1,98 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// response is the outcome of sending a request, with its body already read so it may be
+// shared between the callers of coalesced requests. It must not be modified once returned
+type response struct {
+	resp        *http.Response
+	data        []byte
+	attempts    int
+	notModified bool
+	// readErr is set if the request was sent but its body could not be read
+	readErr bool
+	err     error
+}
+
+// flightGroup coalesces identical requests made concurrently into a single request
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+type flight struct {
+	done    chan struct{}
+	res     response
+	waiters int
+	cancel  context.CancelFunc
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{flights: map[string]*flight{}}
+}
+
+// flightKey identifies requests which may be coalesced, those with the same method, URL, authorization
+// and cache mode, so requests bypassing the cache are not answered from stored validators
+func flightKey(req *RequestBuilder) string {
+	return fmt.Sprintf("%s %s %s %d", req.Method, req.URL, req.Header.Get("Authorization"), req.cache)
+}
+
+// do calls fn once for all callers waiting on the same key, reporting whether the response
+// was shared with a call made by another caller.
+//
+// fn is called with a context carrying the values of the first caller's ctx, which is only canceled
+// once every caller waiting on it has given up, so one caller canceling does not fail the others
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) response) (response, bool) {
+	g.mu.Lock()
+	f, shared := g.flights[key]
+	if shared {
+		f.waiters++
+	} else {
+		flightCtx, cancel := context.WithCancel(detachedContext{ctx})
+		f = &flight{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		g.flights[key] = f
+		go func() {
+			f.res = fn(flightCtx)
+			g.mu.Lock()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+			g.mu.Unlock()
+			close(f.done)
+			cancel()
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.res, shared
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			f.cancel()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+		}
+		g.mu.Unlock()
+		return response{err: ctx.Err()}, shared
+	}
+}
+
+// detachedContext keeps the values of its parent without its deadline or cancellation
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}               { return nil }
+func (detachedContext) Err() error                          { return nil }
+func (d detachedContext) Value(key interface{}) interface{} { return d.parent.Value(key) }
@@ -0,0 +1,160 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoalesceRequests(t *testing.T) {
+	var (
+		requests int32
+		gate     atomic.Value
+	)
+	// block holds responses until the returned channel is closed
+	block := func() chan struct{} {
+		release := make(chan struct{})
+		gate.Store(release)
+		return release
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-gate.Load().(chan struct{})
+		w.Write([]byte(`{"docs": [{"_id": "5cd95395de30eff6ebccde5b", "name": "The Two Towers"}, {"_id": "5cd95395de30eff6ebccde5c", "name": "The Fellowship of the Ring"}]}`))
+	}))
+	defer server.Close()
+
+	// waiting reports the number of callers waiting on requests in flight
+	waiting := func(client OneAPIClient) int {
+		client.flights.mu.Lock()
+		defer client.flights.mu.Unlock()
+		n := 0
+		for _, f := range client.flights.flights {
+			n += f.waiters
+		}
+		return n
+	}
+
+	t.Run("identical requests", func(t *testing.T) {
+		assert := assert.New(t)
+		atomic.StoreInt32(&requests, 0)
+		release := block()
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key", CoalesceRequests: true})
+
+		const callers = 10
+		var wg sync.WaitGroup
+		results := make([][]Movie, callers)
+		errs := make([]error, callers)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], errs[i] = client.Movies().List(WithLimit(2))
+				// callers must not see each other's changes
+				if errs[i] == nil {
+					results[i][0].Name = fmt.Sprint(i)
+				}
+			}(i)
+		}
+		assert.Eventually(func() bool { return waiting(client) == callers }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(int32(1), atomic.LoadInt32(&requests))
+		for i := range results {
+			assert.Nil(errs[i])
+			assert.Equal([]Movie{
+				{ID: "5cd95395de30eff6ebccde5b", Name: fmt.Sprint(i)},
+				{ID: "5cd95395de30eff6ebccde5c", Name: "The Fellowship of the Ring"},
+			}, results[i])
+		}
+		assert.Equal(0, waiting(client))
+
+		// later requests are sent again
+		_, err := client.Movies().List(WithLimit(2))
+		assert.Nil(err)
+		assert.Equal(int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("different requests", func(t *testing.T) {
+		assert := assert.New(t)
+		atomic.StoreInt32(&requests, 0)
+		release := block()
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key", CoalesceRequests: true})
+		other := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "other-key", CoalesceRequests: true})
+		other.flights = client.flights
+
+		calls := []func() error{
+			func() error { _, err := client.Movies().List(); return err },
+			func() error { _, err := client.Movies().List(WithLimit(1)); return err },
+			func() error { _, err := client.Books().List(); return err },
+			func() error { _, err := other.Movies().List(); return err },
+			func() error { _, err := client.Movies().List(WithCacheBypass()); return err },
+		}
+		var wg sync.WaitGroup
+		for _, call := range calls {
+			wg.Add(1)
+			go func(call func() error) {
+				defer wg.Done()
+				assert.Nil(call())
+			}(call)
+		}
+		assert.Eventually(func() bool { return atomic.LoadInt32(&requests) == int32(len(calls)) }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+	})
+
+	t.Run("canceled caller", func(t *testing.T) {
+		assert := assert.New(t)
+		atomic.StoreInt32(&requests, 0)
+		release := block()
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key", CoalesceRequests: true})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		first := make(chan error)
+		go func() {
+			_, err := client.Movies().ListContext(ctx)
+			first <- err
+		}()
+		assert.Eventually(func() bool { return waiting(client) == 1 }, time.Second, time.Millisecond)
+
+		second := make(chan error)
+		go func() {
+			_, err := client.Movies().List()
+			second <- err
+		}()
+		assert.Eventually(func() bool { return waiting(client) == 2 }, time.Second, time.Millisecond)
+
+		// the request is still sent for the remaining caller
+		cancel()
+		assert.ErrorIs(<-first, context.Canceled)
+		close(release)
+		assert.Nil(<-second)
+		assert.Equal(int32(1), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		release := block()
+		close(release)
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key"})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				client.Movies().List()
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(5), atomic.LoadInt32(&requests))
+	})
+}
@@ -0,0 +1,157 @@
+package sdk
+
+import (
+	"container/list"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Validators are the validators returned by the API for a response along with its body,
+// used to make conditional requests which are answered with 304 Not Modified if the response is unchanged
+type Validators struct {
+	// ETag is the ETag header of the response
+	ETag string `json:"etag,omitempty"`
+	// LastModified is the Last-Modified header of the response
+	LastModified string `json:"lastModified,omitempty"`
+	// Body is the body of the response, served again when the API responds with 304 Not Modified
+	Body []byte `json:"body"`
+}
+
+// ValidatorStore stores the Validators of the latest response from each endpoint.
+// Implementations must be safe for concurrent use
+type ValidatorStore interface {
+	// Get returns the validators stored for key
+	Get(key string) (Validators, bool)
+	// Set stores the validators for key
+	Set(key string, v Validators)
+}
+
+// MemoryValidatorStore is a ValidatorStore holding validators in memory for up to a fixed number of endpoints,
+// evicting the least recently used once full. As each page or batch of a listing is a separate endpoint,
+// the size bounds the number of response bodies kept
+type MemoryValidatorStore struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type validatorEntry struct {
+	key        string
+	validators Validators
+}
+
+// NewMemoryValidatorStore creates an empty MemoryValidatorStore holding the validators of up to size endpoints
+func NewMemoryValidatorStore(size int) *MemoryValidatorStore {
+	if size < 1 {
+		size = 1
+	}
+	return &MemoryValidatorStore{
+		size:    size,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// Get returns the validators stored for key
+func (s *MemoryValidatorStore) Get(key string) (Validators, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el, ok := s.entries[key]
+	if !ok {
+		return Validators{}, false
+	}
+	s.order.MoveToFront(el)
+	return el.Value.(*validatorEntry).validators, true
+}
+
+// Set stores the validators for key, evicting the least recently used if the store is full
+func (s *MemoryValidatorStore) Set(key string, v Validators) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.entries[key]; ok {
+		el.Value.(*validatorEntry).validators = v
+		s.order.MoveToFront(el)
+		return
+	}
+	s.entries[key] = s.order.PushFront(&validatorEntry{key: key, validators: v})
+	for s.order.Len() > s.size {
+		el := s.order.Back()
+		s.order.Remove(el)
+		delete(s.entries, el.Value.(*validatorEntry).key)
+	}
+}
+
+// Len returns the number of endpoints whose validators are stored
+func (s *MemoryValidatorStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+type cacheValidatorStore struct {
+	cache Cache
+	ttl   time.Duration
+}
+
+// NewCacheValidatorStore creates a ValidatorStore keeping validators in a Cache for up to ttl,
+// such as a DiskCache allowing validators to be reused across runs
+func NewCacheValidatorStore(cache Cache, ttl time.Duration) ValidatorStore {
+	return cacheValidatorStore{cache: cache, ttl: ttl}
+}
+
+func (s cacheValidatorStore) Get(key string) (Validators, bool) {
+	data, ok := s.cache.Get("validators:" + key)
+	if !ok {
+		return Validators{}, false
+	}
+	var v Validators
+	if err := json.Unmarshal(data, &v); err != nil {
+		return Validators{}, false
+	}
+	return v, true
+}
+
+func (s cacheValidatorStore) Set(key string, v Validators) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	s.cache.Set("validators:"+key, data, s.ttl)
+}
+
+// setConditional adds the validators stored for key to req,
+// returning the body to serve if the API responds with 304 Not Modified
+func setConditional(store ValidatorStore, key string, req *http.Request) ([]byte, bool) {
+	if store == nil {
+		return nil, false
+	}
+	v, ok := store.Get(key)
+	if !ok || (v.ETag == "" && v.LastModified == "") {
+		return nil, false
+	}
+	if v.ETag != "" {
+		req.Header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		req.Header.Set("If-Modified-Since", v.LastModified)
+	}
+	return v.Body, true
+}
+
+// storeValidators stores the validators of a successful response, if it has any
+func storeValidators(store ValidatorStore, key string, resp *http.Response, body []byte) {
+	if store == nil {
+		return
+	}
+	v := Validators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Body:         body,
+	}
+	if v.ETag != "" || v.LastModified != "" {
+		store.Set(key, v)
+	}
+}
@@ -0,0 +1,125 @@
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionalRequests(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		mu          sync.Mutex
+		version     = 1
+		notModified int
+		conditions  []string
+	)
+	lastModified := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		etag := fmt.Sprintf(`"v%d"`, version)
+		conditions = append(conditions, r.Header.Get("If-None-Match")+"|"+r.Header.Get("If-Modified-Since"))
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified)
+		fmt.Fprintf(w, `{"docs": [{"_id": "%s", "name": "The Fellowship Of The Ring v%d"}]}`, testID, version)
+	}))
+	defer server.Close()
+	lastConditions := func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return conditions[len(conditions)-1]
+	}
+
+	t.Run("unchanged responses are served from the store", func(t *testing.T) {
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, Validators: NewMemoryValidatorStore(100)})
+
+		book, err := client.Books().Get(testID)
+		assert.Nil(err)
+		assert.Equal("The Fellowship Of The Ring v1", book.Name)
+		assert.Equal("|", lastConditions())
+
+		book, err = client.Books().Get(testID)
+		assert.Nil(err)
+		assert.Equal("The Fellowship Of The Ring v1", book.Name)
+		assert.Equal(`"v1"|`+lastModified, lastConditions())
+		assert.Equal(1, notModified)
+
+		// a changed resource is downloaded again
+		mu.Lock()
+		version = 2
+		mu.Unlock()
+		book, err = client.Books().Get(testID)
+		assert.Nil(err)
+		assert.Equal("The Fellowship Of The Ring v2", book.Name)
+
+		book, err = client.Books().Get(testID)
+		assert.Nil(err)
+		assert.Equal("The Fellowship Of The Ring v2", book.Name)
+		assert.Equal(2, notModified)
+
+		// bypassing the cache sends an unconditional request
+		_, err = client.Books().Get(testID, WithCacheBypass())
+		assert.Nil(err)
+		assert.Equal("|", lastConditions())
+	})
+
+	t.Run("with a cache", func(t *testing.T) {
+		store, err := NewDiskCache(t.TempDir())
+		assert.Nil(err)
+		cache := &CachePolicy{Store: NewLRUCache(10)}
+		client := NewWithConfig(ClientConfig{
+			BaseURL:    server.URL,
+			Cache:      cache,
+			Validators: NewCacheValidatorStore(store, time.Hour),
+		})
+
+		books, err := client.Books().List()
+		assert.Nil(err)
+		assert.Len(books, 1)
+
+		// refreshing revalidates the cached response
+		books, err = client.Books().List(WithCacheRefresh())
+		assert.Nil(err)
+		assert.Equal("The Fellowship Of The Ring v2", books[0].Name)
+		assert.Equal(`"v2"|`+lastModified, lastConditions())
+
+		_, err = client.Books().List()
+		assert.Nil(err)
+		assert.Equal(CacheStats{Hits: 1, Misses: 1}, cache.Stats())
+	})
+}
+
+func TestMemoryValidatorStore_evicts(t *testing.T) {
+	assert := assert.New(t)
+	store := NewMemoryValidatorStore(2)
+	store.Set("a", Validators{ETag: `"a"`})
+	store.Set("b", Validators{ETag: `"b"`})
+	_, ok := store.Get("a")
+	assert.True(ok)
+
+	// b is the least recently used
+	store.Set("c", Validators{ETag: `"c"`})
+	assert.Equal(2, store.Len())
+	_, ok = store.Get("b")
+	assert.False(ok)
+	v, ok := store.Get("a")
+	assert.True(ok)
+	assert.Equal(`"a"`, v.ETag)
+
+	store.Set("a", Validators{ETag: `"a2"`})
+	v, _ = store.Get("a")
+	assert.Equal(`"a2"`, v.ETag)
+	assert.Equal(2, store.Len())
+}
@@ -0,0 +1,279 @@
+package sdk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Age is an age of Middle-earth
+type Age int
+
+const (
+	// AgeUnknown is the age of dates which could not be parsed, such as "NaN"
+	AgeUnknown Age = iota
+	// YearsOfTheTrees is the age before the first rising of the Sun, written as YT
+	YearsOfTheTrees
+	// FirstAge is written as FA
+	FirstAge
+	// SecondAge is written as SA
+	SecondAge
+	// ThirdAge is written as TA
+	ThirdAge
+	// FourthAge is written as FO
+	FourthAge
+)
+
+// ageLengths are the number of years in each age of the Sun which has ended
+var ageLengths = map[Age]int{
+	FirstAge:  590,
+	SecondAge: 3441,
+	ThirdAge:  3021,
+}
+
+// ageNames are the abbreviations and names by which ages are written
+var ageNames = map[string]Age{
+	"yt":                 YearsOfTheTrees,
+	"years of the trees": YearsOfTheTrees,
+	"fa":                 FirstAge,
+	"first age":          FirstAge,
+	"sa":                 SecondAge,
+	"second age":         SecondAge,
+	"ta":                 ThirdAge,
+	"third age":          ThirdAge,
+	"fo":                 FourthAge,
+	"foa":                FourthAge,
+	"fourth age":         FourthAge,
+}
+
+func (a Age) String() string {
+	switch a {
+	case YearsOfTheTrees:
+		return "YT"
+	case FirstAge:
+		return "FA"
+	case SecondAge:
+		return "SA"
+	case ThirdAge:
+		return "TA"
+	case FourthAge:
+		return "FO"
+	}
+	return "unknown"
+}
+
+// Period is the part of an age given by dates without a year, such as "Late, Third Age"
+type Period int
+
+const (
+	// PeriodNone is the period of dates with a year or without a period
+	PeriodNone Period = iota
+	// PeriodEarly is the beginning of an age
+	PeriodEarly
+	// PeriodMid is the middle of an age
+	PeriodMid
+	// PeriodLate is the end of an age
+	PeriodLate
+)
+
+// periodNames are the words by which periods are written
+var periodNames = map[string]Period{
+	"early":  PeriodEarly,
+	"mid":    PeriodMid,
+	"middle": PeriodMid,
+	"late":   PeriodLate,
+}
+
+// MiddleEarthDate is a date such as the birth or death of a Character, parsed from text such as
+// "TA 2931", "SA 3441" or "Late ,Third Age". Text which cannot be parsed, such as "NaN",
+// results in a date of AgeUnknown which keeps the original text.
+//
+// Compare and Less order dates chronologically across ages, with approximate dates ordered by an
+// estimate of their year. The API and Evaluate order them by their text instead. Dates encode to
+// and from their original text
+type MiddleEarthDate struct {
+	// Age is the age of the date, or AgeUnknown if it could not be parsed
+	Age Age
+	// Year is the year within Age, or zero if not given
+	Year int
+	// Period is the part of Age given instead of a year
+	Period Period
+	// Approximate is set for dates which are not exact, such as those given by a period,
+	// qualified with "c." or "around", or giving alternative years
+	Approximate bool
+	// Before is set for dates qualified with "before", such as "Before TA 1944"
+	Before bool
+	// After is set for dates qualified with "after"
+	After bool
+	// Text is the original text of the date
+	Text string
+}
+
+// ParseMiddleEarthDate parses a date such as "TA 2931" as written by the API.
+// Only the first date is used of text giving several, such as "TA 2978 or 2979"
+func ParseMiddleEarthDate(text string) MiddleEarthDate {
+	d := MiddleEarthDate{Text: text}
+	words := strings.Fields(strings.NewReplacer(",", " ", "~", " ~ ").Replace(strings.ToLower(text)))
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if d.Age == AgeUnknown {
+			if age, n := parseAge(words[i:]); n > 0 {
+				d.Age = age
+				i += n - 1
+				continue
+			}
+		}
+		switch word {
+		case "c.", "c", "ca.", "circa", "around", "about", "approx.", "~", "or", "and", "between", "-":
+			d.Approximate = true
+		case "before":
+			d.Before = true
+		case "after":
+			d.After = true
+		default:
+			if period, ok := periodNames[word]; ok {
+				d.Period = period
+				d.Approximate = true
+			} else if year, err := strconv.Atoi(strings.TrimSuffix(word, "s")); err == nil && year > 0 && d.Age != AgeUnknown {
+				// numbers before the age are days of the month, as in "March 1 ,TA 2931"
+				d.Year = year
+				// a decade such as TA 2980s
+				d.Approximate = d.Approximate || strings.HasSuffix(word, "s")
+			}
+		}
+		// ignore any following dates once the first is complete
+		if d.Age != AgeUnknown && d.Year != 0 {
+			d.Approximate = d.Approximate || hasAlternative(words[i+1:])
+			break
+		}
+	}
+	if d.Age == AgeUnknown {
+		return MiddleEarthDate{Text: text}
+	}
+	return d
+}
+
+// parseAge parses the age at the start of words, returning the number of words used
+func parseAge(words []string) (Age, int) {
+	for n := len(words); n > 0; n-- {
+		if age, ok := ageNames[strings.Join(words[:n], " ")]; ok {
+			return age, n
+		}
+	}
+	return AgeUnknown, 0
+}
+
+// hasAlternative reports whether words give another date, as in "TA 2978 or 2979"
+func hasAlternative(words []string) bool {
+	for _, word := range words {
+		if word == "or" || word == "-" || word == "and" {
+			return true
+		}
+	}
+	return false
+}
+
+// Known reports whether the age of the date could be parsed
+func (d MiddleEarthDate) Known() bool {
+	return d.Age != AgeUnknown
+}
+
+// String returns the original text of the date, or the date formatted as "TA 2931" if there is none
+func (d MiddleEarthDate) String() string {
+	switch {
+	case d.Text != "" || !d.Known():
+		return d.Text
+	case d.Year == 0:
+		return d.Age.String()
+	}
+	return fmt.Sprintf("%s %d", d.Age, d.Year)
+}
+
+// MarshalText encodes the date as its original text
+func (d MiddleEarthDate) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses the date with ParseMiddleEarthDate, so never fails
+func (d *MiddleEarthDate) UnmarshalText(text []byte) error {
+	*d = ParseMiddleEarthDate(string(text))
+	return nil
+}
+
+// absolute returns the estimated year of the date counted from the start of the First Age,
+// with the Years of the Trees counting down to it
+func (d MiddleEarthDate) absolute() (float64, bool) {
+	if !d.Known() {
+		return 0, false
+	}
+	year := float64(d.Year)
+	if d.Year == 0 {
+		length := ageLengths[d.Age]
+		if length == 0 {
+			length = 1500
+		}
+		switch d.Period {
+		case PeriodEarly:
+			year = float64(length) * 0.1
+		case PeriodMid:
+			year = float64(length) * 0.5
+		case PeriodLate:
+			year = float64(length) * 0.9
+		}
+	}
+	switch {
+	case d.Before:
+		year -= 0.5
+	case d.After:
+		year += 0.5
+	}
+	if d.Age == YearsOfTheTrees {
+		return year - 1501, true
+	}
+	for age := FirstAge; age < d.Age; age++ {
+		year += float64(ageLengths[age])
+	}
+	return year, true
+}
+
+// Compare returns -1 if d is earlier than other, 1 if it is later, and 0 if they are the same
+// or the order cannot be told. Unknown dates are ordered after all known dates
+func (d MiddleEarthDate) Compare(other MiddleEarthDate) int {
+	a, aok := d.absolute()
+	b, bok := other.absolute()
+	switch {
+	case aok && !bok:
+		return -1
+	case !aok && bok:
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Less reports whether d is earlier than other
+func (d MiddleEarthDate) Less(other MiddleEarthDate) bool {
+	return d.Compare(other) < 0
+}
+
+// Lifespan returns the number of years between birth and death, reporting false if either
+// is unknown or without a year. Years of the Trees cannot be compared with the ages of the Sun
+func Lifespan(birth, death MiddleEarthDate) (int, bool) {
+	if !birth.Known() || !death.Known() || birth.Year == 0 || death.Year == 0 {
+		return 0, false
+	}
+	if (birth.Age == YearsOfTheTrees) != (death.Age == YearsOfTheTrees) {
+		return 0, false
+	}
+	// only the years are counted, not whether the dates are before or after them
+	birth.Before, birth.After, death.Before, death.After = false, false, false, false
+	b, _ := birth.absolute()
+	d, _ := death.absolute()
+	if b > d {
+		return 0, false
+	}
+	return int(d - b), true
+}
@@ -0,0 +1,127 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMiddleEarthDate(t *testing.T) {
+	tests := []struct {
+		text string
+		want MiddleEarthDate
+	}{
+		{"TA 2931", MiddleEarthDate{Age: ThirdAge, Year: 2931}},
+		{"FA 1", MiddleEarthDate{Age: FirstAge, Year: 1}},
+		{"SA 3441", MiddleEarthDate{Age: SecondAge, Year: 3441}},
+		{"FO 61", MiddleEarthDate{Age: FourthAge, Year: 61}},
+		{"YT 1050", MiddleEarthDate{Age: YearsOfTheTrees, Year: 1050}},
+		{"Late ,Third Age", MiddleEarthDate{Age: ThirdAge, Period: PeriodLate, Approximate: true}},
+		{"Mid ,First Age", MiddleEarthDate{Age: FirstAge, Period: PeriodMid, Approximate: true}},
+		{"Before ,TA 1944", MiddleEarthDate{Age: ThirdAge, Year: 1944, Before: true}},
+		{"After SA 3441", MiddleEarthDate{Age: SecondAge, Year: 3441, After: true}},
+		{"c. TA 2850", MiddleEarthDate{Age: ThirdAge, Year: 2850, Approximate: true}},
+		{"TA 2978 or 2979", MiddleEarthDate{Age: ThirdAge, Year: 2978, Approximate: true}},
+		{"TA 2980s", MiddleEarthDate{Age: ThirdAge, Year: 2980, Approximate: true}},
+		{"SA 3319 ,TA 3019", MiddleEarthDate{Age: SecondAge, Year: 3319}},
+		{"March 1 ,TA 2931", MiddleEarthDate{Age: ThirdAge, Year: 2931}},
+		{"NaN", MiddleEarthDate{}},
+		{"", MiddleEarthDate{}},
+		{"Unknown", MiddleEarthDate{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			tt.want.Text = tt.text
+			got := ParseMiddleEarthDate(tt.text)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want.Age != AgeUnknown, got.Known())
+			assert.Equal(t, tt.text, got.String())
+		})
+	}
+}
+
+func TestMiddleEarthDate_Compare(t *testing.T) {
+	assert := assert.New(t)
+	dates := []string{"YT 1050", "FA 1", "Mid ,First Age", "SA 1", "Before ,TA 1944", "TA 1944", "Late ,Third Age", "FO 61", "NaN"}
+	for i := range dates {
+		for j := range dates {
+			a, b := ParseMiddleEarthDate(dates[i]), ParseMiddleEarthDate(dates[j])
+			switch {
+			case i < j:
+				assert.Equal(-1, a.Compare(b), "%s < %s", dates[i], dates[j])
+				assert.True(a.Less(b))
+			case i > j:
+				assert.Equal(1, a.Compare(b), "%s > %s", dates[i], dates[j])
+			default:
+				assert.Equal(0, a.Compare(b))
+			}
+		}
+	}
+}
+
+func TestLifespan(t *testing.T) {
+	tests := []struct {
+		birth, death string
+		years        int
+		ok           bool
+	}{
+		{"TA 2968", "FO 61", 114, true},
+		{"TA 2931", "FO 120", 210, true},
+		{"SA 3209", "TA 3019", 3251, true},
+		{"Before ,TA 1944", "TA 2019", 75, true},
+		{"YT 1050", "YT 1497", 447, true},
+		{"YT 1050", "FA 1", 0, false},
+		{"Late ,Third Age", "FO 61", 0, false},
+		{"TA 2931", "NaN", 0, false},
+		{"FO 61", "TA 2968", 0, false},
+	}
+	for _, tt := range tests {
+		years, ok := Lifespan(ParseMiddleEarthDate(tt.birth), ParseMiddleEarthDate(tt.death))
+		assert.Equal(t, tt.years, years, "%s to %s", tt.birth, tt.death)
+		assert.Equal(t, tt.ok, ok, "%s to %s", tt.birth, tt.death)
+	}
+
+	aragorn := Character{Birth: "TA 2931", Death: "FO 120"}
+	years, ok := aragorn.Lifespan()
+	assert.True(t, ok)
+	assert.Equal(t, 210, years)
+}
+
+func TestMiddleEarthDate_json(t *testing.T) {
+	assert := assert.New(t)
+	var c Character
+	assert.Nil(json.Unmarshal([]byte(`{"name": "Aragorn II Elessar", "birth": "March 1 ,TA 2931", "death": "NaN"}`), &c))
+	assert.Equal(ThirdAge, c.BirthDate().Age)
+	assert.Equal(2931, c.BirthDate().Year)
+	assert.False(c.DeathDate().Known())
+
+	data, err := json.Marshal(c)
+	assert.Nil(err)
+	assert.Contains(string(data), `"birth":"March 1 ,TA 2931"`)
+	assert.Contains(string(data), `"death":"NaN"`)
+}
+
+func TestEvaluate_dates(t *testing.T) {
+	assert := assert.New(t)
+	characters := []Character{
+		{ID: "1", Name: "Aragorn", Birth: "TA 2931"},
+		{ID: "2", Name: "Elrond", Birth: "FA 532"},
+		{ID: "3", Name: "Gandalf", Birth: "NaN"},
+		{ID: "4", Name: "Frodo", Birth: "TA 2968"},
+		{ID: "5", Name: "Isildur", Birth: "SA 3209"},
+	}
+
+	// dates are sorted as text, as by the API
+	page, err := Evaluate(characters, WithSort("birth", "asc"))
+	assert.Nil(err)
+	assert.Equal([]string{"2", "3", "5", "1", "4"}, characterIDs(page))
+
+	page, err = Evaluate(characters, WithFilterMatch("birth", "TA 2931"))
+	assert.Nil(err)
+	assert.Equal([]string{"1"}, characterIDs(page))
+
+	page, err = Evaluate(characters, WithRegexInclude("birth", "/^TA/"))
+	assert.Nil(err)
+	assert.Equal([]string{"1", "4"}, characterIDs(page))
+}
@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrUnknownField is matched by errors decoding a response containing a field not present
+// in the resource's model, returned when the client is configured with StrictDecoding
+var ErrUnknownField = errors.New("unknown field")
+
+// extraType is the type of the Extra field of models, holding the fields without a struct field
+var extraType = reflect.TypeOf(map[string]json.RawMessage{})
+
+// decode unmarshals the response data into v. Fields of the docs without a struct field are
+// kept in the Extra field of each doc, or cause an error matching ErrUnknownField if strict
+func (c OneAPIClient) decode(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if c.strict {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		// the decoder does not provide a typed error for unknown fields
+		if strings.HasPrefix(err.Error(), "json: unknown field") {
+			return fmt.Errorf("%w: %v", ErrUnknownField, err)
+		}
+		return err
+	}
+	return keepExtra(data, v)
+}
+
+// keepExtra sets the Extra field of each doc in v to the fields of the doc in data
+// which were not decoded into another field
+func keepExtra(data []byte, v interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	docs := rv.FieldByName("Docs")
+	if !docs.IsValid() || docs.Kind() != reflect.Slice || docs.Len() == 0 {
+		return nil
+	}
+	extra, ok := docs.Type().Elem().FieldByName("Extra")
+	if !ok || extra.Type != extraType {
+		return nil
+	}
+
+	var raw struct {
+		Docs []map[string]json.RawMessage `json:"docs"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	known := fieldsOf(docs.Type().Elem())
+	for i := 0; i < docs.Len() && i < len(raw.Docs); i++ {
+		unknown := map[string]json.RawMessage{}
+		for key, value := range raw.Docs[i] {
+			if _, ok := known[key]; !ok {
+				unknown[key] = value
+			}
+		}
+		if len(unknown) > 0 {
+			docs.Index(i).FieldByIndex(extra.Index).Set(reflect.ValueOf(unknown))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode_extraFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"docs": [
+			{"_id": "5cd96e05de30eff6ebcce7e9", "id": "5cd96e05de30eff6ebcce7e9", "dialog": "Deagol!", "movie": "5cd95395de30eff6ebccde5d", "character": "5cd99d4bde30eff6ebccfe9e", "language": {"name": "Westron"}},
+			{"_id": "5cd96e05de30eff6ebcce7ea", "dialog": "Smeagol!"}
+		], "total": 2, "limit": 1000, "offset": 0, "page": 1, "pages": 1}`))
+	}))
+	defer server.Close()
+
+	t.Run("kept", func(t *testing.T) {
+		assert := assert.New(t)
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key"})
+		quotes, err := client.Quotes().List()
+		assert.Nil(err)
+		assert.Len(quotes, 2)
+		assert.Equal("5cd95395de30eff6ebccde5d", quotes[0].Movie)
+		assert.Equal(quotes[0].ID, quotes[0].QuoteID)
+		assert.Equal(map[string]json.RawMessage{
+			"language": json.RawMessage(`{"name": "Westron"}`),
+		}, quotes[0].Extra)
+		assert.Nil(quotes[1].Extra)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		assert := assert.New(t)
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key", StrictDecoding: true})
+		_, err := client.Quotes().List()
+		assert.ErrorIs(err, ErrUnknownField)
+		assert.ErrorIs(err, SDKError{Kind: ErrorKindDeserialization})
+
+		movies, err := client.Movies().List()
+		assert.ErrorIs(err, ErrUnknownField)
+		assert.Nil(movies)
+
+		// responses matching the models are decoded as usual
+		_, err = NewWithConfig(ClientConfig{Client: newTestFake().Client().client, BaseURL: "http://fake.the-one-api.dev/v2", ApiKey: "fake", StrictDecoding: true}).
+			Characters().List()
+		assert.Nil(err)
+	})
+}
@@ -0,0 +1,181 @@
+package sdk
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// maxBodySnippet is the number of bytes of a response body kept in a StatusError
+const maxBodySnippet = 256
+
+// Sentinel errors which may be used with errors.Is to determine
+// why the API rejected a request
+var (
+	// ErrBadRequest indicates the API responded with 400 Bad Request
+	ErrBadRequest = errors.New("bad request")
+	// ErrUnauthorized indicates the API responded with 401 Unauthorized,
+	// usually because an API key is missing or invalid
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden indicates the API responded with 403 Forbidden
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound indicates the API responded with 404 Not Found
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited indicates the API responded with 429 Too Many Requests
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServer indicates the API responded with a 5xx status code
+	ErrServer = errors.New("server error")
+	// ErrInvalidID indicates a resource ID is not a valid 24 character hex ObjectID
+	ErrInvalidID = errors.New("invalid id")
+)
+
+// StatusError is returned when the API responds with a non-2xx status code
+//
+//	_, err := client.Characters().Get(id)
+//	if errors.Is(err, sdk.ErrNotFound) {
+//		...
+//	}
+type StatusError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Endpoint is the path of the request
+	Endpoint string
+	// RequestID is the ID assigned to the request by the server, if provided
+	RequestID string
+	// Message is the error message provided by the API, if any
+	Message string
+	// Body is the beginning of the response body, truncated to a few hundred bytes
+	Body string
+}
+
+func newStatusError(path string, resp *http.Response, body []byte) StatusError {
+	e := StatusError{
+		StatusCode: resp.StatusCode,
+		Endpoint:   path,
+	}
+	for _, h := range []string{"X-Request-Id", "X-Correlation-Id", "CF-Ray"} {
+		if id := resp.Header.Get(h); id != "" {
+			e.RequestID = id
+			break
+		}
+	}
+
+	apiErr := APIError{}
+	if err := json.Unmarshal(body, &apiErr); err == nil {
+		e.Message = apiErr.Message
+	}
+
+	if len(body) > maxBodySnippet {
+		e.Body = string(body[:maxBodySnippet]) + "..."
+	} else {
+		e.Body = string(body)
+	}
+	return e
+}
+
+// statusError returns the kind and error describing a non-2xx response
+func statusError(path string, resp *http.Response, body []byte) (ErrorKind, error) {
+	statusErr := newStatusError(path, resp, body)
+	kind := ErrorKindHTTP
+	if statusErr.Message != "" {
+		kind = ErrorKindAPI
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		rateErr := parseRateLimit(resp, time.Now())
+		rateErr.StatusError = statusErr
+		return kind, rateErr
+	}
+	return kind, statusErr
+}
+
+func (e StatusError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.RequestID != "" {
+		return fmt.Sprintf("%d %s (request %s)", e.StatusCode, msg, e.RequestID)
+	}
+	return fmt.Sprintf("%d %s", e.StatusCode, msg)
+}
+
+// Is reports whether the status code corresponds to the target sentinel error
+func (e StatusError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// Unwrap returns the APIError provided in the response body, if any
+func (e StatusError) Unwrap() error {
+	if e.Message == "" {
+		return nil
+	}
+	return APIError{Success: false, Message: e.Message}
+}
+
+// NotFoundError is returned when a requested resource does not exist.
+// It matches ErrNotFound when used with errors.Is
+type NotFoundError struct {
+	// Resource is the type of resource requested, such as "character"
+	Resource string
+	// ID is the requested ID
+	ID string
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Resource, e.ID)
+}
+
+// Is reports whether target is ErrNotFound
+func (e NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+func notFoundError(path string, resource string, id string) error {
+	return SDKError{
+		Kind:     ErrorKindAPI,
+		Method:   http.MethodGet,
+		Endpoint: path,
+		Err:      NotFoundError{Resource: resource, ID: id},
+	}
+}
+
+// noQuotesError is returned when a movie or character has no quotes. It matches ErrNotFound
+func noQuotesError(path string) error {
+	return SDKError{
+		Kind:     ErrorKindAPI,
+		Method:   http.MethodGet,
+		Endpoint: path,
+		Err:      fmt.Errorf("%w: no quotes available", ErrNotFound),
+	}
+}
+
+// validateID ensures id is a valid ObjectID as used by The One API,
+// so malformed IDs are caught before making a request
+func validateID(path string, id string) error {
+	if _, err := hex.DecodeString(id); len(id) != 24 || err != nil {
+		return SDKError{
+			Kind:     ErrorKindRequest,
+			Method:   http.MethodGet,
+			Endpoint: path,
+			Err:      fmt.Errorf("%w: %q is not a 24 character hex string", ErrInvalidID, id),
+		}
+	}
+	return nil
+}
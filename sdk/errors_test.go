@@ -0,0 +1,158 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	longBody := strings.Repeat("x", 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		switch r.URL.Path {
+		case "/book":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"success": false, "message": "bad query"}`))
+		case "/movie":
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success": false, "message": "Unauthorized."}`))
+		case "/character":
+			w.WriteHeader(http.StatusForbidden)
+		case "/quote":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("Cannot GET /v2/quote"))
+		case "/chapter":
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"success": false, "message": "Too many requests"}`))
+		case "/book/5cf58077b53e011a64671583/chapter":
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(longBody))
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key"})
+
+	tests := []struct {
+		name     string
+		call     func() error
+		sentinel error
+		status   int
+		endpoint string
+		message  string
+	}{
+		{"bad request", func() error { _, err := client.Books().List(); return err }, ErrBadRequest, 400, "/book", "bad query"},
+		{"unauthorized", func() error { _, err := client.Movies().List(); return err }, ErrUnauthorized, 401, "/movie", "Unauthorized."},
+		{"forbidden", func() error { _, err := client.Characters().List(); return err }, ErrForbidden, 403, "/character", ""},
+		{"not found", func() error { _, err := client.Quotes().List(); return err }, ErrNotFound, 404, "/quote", ""},
+		{"rate limited", func() error { _, err := client.Chapters().List(); return err }, ErrRateLimited, 429, "/chapter", "Too many requests"},
+		{"server", func() error { _, err := client.Books().GetChapters("5cf58077b53e011a64671583"); return err }, ErrServer, 502, "/book/5cf58077b53e011a64671583/chapter", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			assert.ErrorIs(err, tt.sentinel)
+
+			var statusErr StatusError
+			if tt.status == http.StatusTooManyRequests {
+				var rateErr RateLimitError
+				assert.True(errors.As(err, &rateErr))
+				statusErr = rateErr.StatusError
+			} else {
+				assert.True(errors.As(err, &statusErr))
+			}
+			assert.Equal(tt.status, statusErr.StatusCode)
+			assert.Equal(tt.endpoint, statusErr.Endpoint)
+			assert.Equal("req-123", statusErr.RequestID)
+			assert.Equal(tt.message, statusErr.Message)
+			assert.LessOrEqual(len(statusErr.Body), maxBodySnippet+3)
+
+			var apiErr APIError
+			assert.Equal(tt.message != "", errors.As(err, &apiErr))
+
+			for _, other := range []error{ErrBadRequest, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrRateLimited, ErrServer} {
+				if other != tt.sentinel {
+					assert.False(errors.Is(err, other))
+				}
+			}
+		})
+	}
+}
+
+func TestSDKError(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/book":
+			w.Write([]byte(`{"success": false, "message": "sample error message"}`))
+		case "/movie":
+			w.Write([]byte(`not json`))
+		case "/quote":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key"})
+
+	t.Run("api error", func(t *testing.T) {
+		_, err := client.Books().List()
+
+		var sdkErr SDKError
+		assert.True(errors.As(err, &sdkErr))
+		assert.Equal(ErrorKindAPI, sdkErr.Kind)
+		assert.Equal(http.MethodGet, sdkErr.Method)
+		assert.Equal("/book", sdkErr.Endpoint)
+		assert.Equal(http.StatusOK, sdkErr.StatusCode)
+		assert.Equal(1, sdkErr.Attempts)
+
+		var apiErr APIError
+		assert.True(errors.As(err, &apiErr))
+		assert.Equal("sample error message", apiErr.Message)
+		assert.Equal("API Error /book: sample error message", err.Error())
+
+		assert.ErrorIs(err, SDKError{Kind: ErrorKindAPI})
+		assert.ErrorIs(err, SDKError{Endpoint: "/book"})
+		assert.ErrorIs(err, SDKError{Err: APIError{Message: "sample error message"}})
+		assert.False(errors.Is(err, SDKError{Kind: ErrorKindHTTP}))
+		assert.False(errors.Is(err, SDKError{Kind: ErrorKindAPI, Endpoint: "/movie"}))
+	})
+
+	t.Run("deserialization error", func(t *testing.T) {
+		_, err := client.Movies().List()
+		assert.ErrorIs(err, SDKError{Kind: ErrorKindDeserialization, Endpoint: "/movie"})
+
+		var syntaxErr *json.SyntaxError
+		assert.True(errors.As(err, &syntaxErr))
+	})
+
+	t.Run("status error", func(t *testing.T) {
+		_, err := client.Quotes().List()
+		assert.ErrorIs(err, SDKError{Kind: ErrorKindHTTP, StatusCode: http.StatusServiceUnavailable})
+		assert.ErrorIs(err, ErrServer)
+	})
+
+	t.Run("network error", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+		client := NewWithConfig(ClientConfig{BaseURL: closed.URL})
+
+		_, err := client.Books().List()
+		assert.ErrorIs(err, SDKError{Kind: ErrorKindHTTP})
+
+		var netErr net.Error
+		assert.True(errors.As(err, &netErr))
+	})
+
+	assert.Equal("Deserialization Error", ErrorKindDeserialization.String())
+	assert.Equal("Unknown Error", ErrorKindUnknown.String())
+}
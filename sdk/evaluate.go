@@ -0,0 +1,332 @@
+package sdk
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultLimit is the number of resources in a page when no limit is provided, as used by the API
+const defaultLimit = 1000
+
+// Evaluate applies the filters, sorting, and pagination of opts to items as The One API does,
+// returning the resulting page. It allows the same options to be used against local data, such as in tests
+//
+//	page, err := sdk.Evaluate(characters, sdk.WithFilterInclude("race", "Hobbit", "Elf"), sdk.WithLimit(10))
+//
+// Fields are identified by the json tags of T. Text fields are compared as strings and numeric fields as numbers.
+// As with the API, resources without a filtered field only match negated conditions,
+// and values of the form /expr/flags are treated as regular expressions
+func Evaluate[T any](items []T, opts ...RequestOption) (Page[T], error) {
+	rb := applyOptions(opts...)
+	if err := rb.Err(); err != nil {
+		return Page[T]{}, err
+	}
+	q, err := parseQuery(rb.URL.RawQuery)
+	if err != nil {
+		return Page[T]{}, err
+	}
+	return evaluate(items, q)
+}
+
+func evaluate[T any](items []T, q queryString) (Page[T], error) {
+	fields := fieldsOf(reflect.TypeOf(items).Elem())
+
+	var (
+		conditions []condition
+		sorts      []sortKey
+		limit      = defaultLimit
+		page       = 1
+		offset     = -1
+	)
+	for _, p := range q {
+		if reservedParams[p.key] && p.op != OpEq {
+			return Page[T]{}, fmt.Errorf("%w: %q cannot be compared", ErrInvalidQuery, p.key)
+		}
+		var err error
+		switch p.key {
+		case "limit":
+			limit, err = parsePositive(p, 1)
+		case "page":
+			page, err = parsePositive(p, 1)
+		case "offset":
+			offset, err = parsePositive(p, 0)
+		case "sort":
+			var s sortKey
+			s, err = newSortKey(fields, p.val)
+			sorts = append(sorts, s)
+		default:
+			var c condition
+			c, err = newCondition(fields, p)
+			conditions = append(conditions, c)
+		}
+		if err != nil {
+			return Page[T]{}, err
+		}
+	}
+
+	matched := []T{}
+	for _, item := range items {
+		v := reflect.Indirect(reflect.ValueOf(item))
+		ok := true
+		for _, c := range conditions {
+			if !c.match(valueOf(v, c.index)) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, item)
+		}
+	}
+
+	if len(sorts) > 0 {
+		sort.SliceStable(matched, func(i, j int) bool {
+			a := reflect.Indirect(reflect.ValueOf(matched[i]))
+			b := reflect.Indirect(reflect.ValueOf(matched[j]))
+			for _, s := range sorts {
+				if cmp := compareValues(valueOf(a, s.index), valueOf(b, s.index)); cmp != 0 {
+					return (cmp < 0) != s.desc
+				}
+			}
+			return false
+		})
+	}
+
+	total := len(matched)
+	// an offset takes precedence over a page, as with the API.
+	// Pages beyond the results skip all of them, without overflowing
+	skip := total
+	if page-1 <= total/limit {
+		skip = (page - 1) * limit
+	}
+	if offset >= 0 {
+		skip = offset
+		page = offset/limit + 1
+	}
+	pages := total / limit
+	if total%limit != 0 || pages == 0 {
+		pages++
+	}
+	start, end := skip, total
+	if skip < total-limit {
+		end = skip + limit
+	}
+	if start > total {
+		start = total
+	}
+
+	return Page[T]{
+		Items:  matched[start:end],
+		Total:  total,
+		Limit:  limit,
+		Offset: skip,
+		Page:   page,
+		Pages:  pages,
+	}, nil
+}
+
+func parsePositive(p queryParam, least int) (int, error) {
+	n, err := strconv.Atoi(p.val)
+	if err != nil || n < least {
+		return 0, fmt.Errorf("%w: %s must be a number of at least %d, not %q", ErrInvalidQuery, p.key, least, p.val)
+	}
+	return n, nil
+}
+
+// fieldsOf maps the json names of the fields of a struct type to their index
+func fieldsOf(t reflect.Type) map[string][]int {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	fields := map[string][]int{}
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+	for _, f := range reflect.VisibleFields(t) {
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Index
+	}
+	return fields
+}
+
+// fieldValue is the value of a field of a resource, as compared by the API
+type fieldValue struct {
+	present bool
+	numeric bool
+	num     float64
+	str     string
+}
+
+func valueOf(v reflect.Value, index []int) fieldValue {
+	if index == nil || v.Kind() != reflect.Struct {
+		return fieldValue{}
+	}
+	f, err := v.FieldByIndexErr(index)
+	if err != nil {
+		return fieldValue{}
+	}
+	if m, ok := f.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			return fieldValue{}
+		}
+		return fieldValue{present: true, str: string(text)}
+	}
+	switch f.Kind() {
+	case reflect.String:
+		return fieldValue{present: true, str: f.String()}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fieldValue{present: true, numeric: true, num: float64(f.Int())}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fieldValue{present: true, numeric: true, num: float64(f.Uint())}
+	case reflect.Float32, reflect.Float64:
+		return fieldValue{present: true, numeric: true, num: f.Float()}
+	case reflect.Ptr, reflect.Interface:
+		if f.IsNil() {
+			return fieldValue{}
+		}
+	}
+	return fieldValue{present: true, str: fmt.Sprint(f.Interface())}
+}
+
+// compareValues orders missing values before numbers, and numbers before text
+func compareValues(a, b fieldValue) int {
+	rank := func(v fieldValue) int {
+		switch {
+		case !v.present:
+			return 0
+		case v.numeric:
+			return 1
+		}
+		return 2
+	}
+	if ra, rb := rank(a), rank(b); ra != rb {
+		return ra - rb
+	}
+	switch {
+	case a.numeric && a.num < b.num, !a.numeric && a.str < b.str:
+		return -1
+	case a.numeric && a.num > b.num, !a.numeric && a.str > b.str:
+		return 1
+	}
+	return 0
+}
+
+// sortKey is a field by which results are sorted, such as name:desc
+type sortKey struct {
+	index []int
+	desc  bool
+}
+
+func newSortKey(fields map[string][]int, val string) (sortKey, error) {
+	field, dir := val, "asc"
+	if i := strings.LastIndex(val, ":"); i >= 0 {
+		field, dir = val[:i], val[i+1:]
+	}
+	switch {
+	case field == "":
+		return sortKey{}, fmt.Errorf("%w: sort requires a field", ErrInvalidQuery)
+	case dir != string(Asc) && dir != string(Desc):
+		return sortKey{}, fmt.Errorf("%w: unsupported sort direction %q", ErrInvalidQuery, dir)
+	}
+	return sortKey{index: fields[field], desc: dir == string(Desc)}, nil
+}
+
+// regexValue matches values of the form /expr/flags
+var regexValue = regexp.MustCompile(`^/(.*)/([a-z]*)$`)
+
+// condition is a single filter of a query
+type condition struct {
+	index  []int
+	op     Operator
+	values []string
+	num    float64
+	regex  *regexp.Regexp
+}
+
+func newCondition(fields map[string][]int, p queryParam) (condition, error) {
+	c := condition{index: fields[p.key], op: p.op}
+	switch p.op {
+	case OpEq, OpNe:
+		if m := regexValue.FindStringSubmatch(p.val); m != nil {
+			expr := m[1]
+			if m[2] != "" {
+				if strings.Trim(m[2], "ims") != "" {
+					return c, fmt.Errorf("%w: unsupported regular expression flags %q", ErrInvalidQuery, m[2])
+				}
+				expr = fmt.Sprintf("(?%s)%s", m[2], expr)
+			}
+			regex, err := regexp.Compile(expr)
+			if err != nil {
+				return c, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+			}
+			c.regex = regex
+			return c, nil
+		}
+		c.values = strings.Split(p.val, ",")
+	default:
+		num, err := strconv.ParseFloat(p.val, 64)
+		if err != nil || math.IsNaN(num) || math.IsInf(num, 0) {
+			return c, fmt.Errorf("%w: %q is not a valid value to compare %q against", ErrInvalidQuery, p.val, p.key)
+		}
+		c.num = num
+	}
+	return c, nil
+}
+
+func (c condition) match(v fieldValue) bool {
+	switch c.op {
+	case OpEq:
+		return c.equals(v)
+	case OpNe:
+		return !c.equals(v)
+	}
+	if !v.present || !v.numeric {
+		return false
+	}
+	switch c.op {
+	case OpLt:
+		return v.num < c.num
+	case OpLte:
+		return v.num <= c.num
+	case OpGt:
+		return v.num > c.num
+	case OpGte:
+		return v.num >= c.num
+	}
+	return false
+}
+
+// equals reports whether v matches the regular expression or any of the values of the condition
+func (c condition) equals(v fieldValue) bool {
+	if !v.present {
+		return false
+	}
+	if c.regex != nil {
+		return !v.numeric && c.regex.MatchString(v.str)
+	}
+	for _, val := range c.values {
+		if !v.numeric && v.str == val {
+			return true
+		}
+		if num, err := strconv.ParseFloat(val, 64); err == nil && v.numeric && v.num == num {
+			return true
+		}
+	}
+	return false
+}
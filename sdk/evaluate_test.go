@@ -0,0 +1,184 @@
+package sdk
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testCharacters = []Character{
+	{ID: "1", Name: "Frodo Baggins", Race: "Hobbit", Gender: "Male"},
+	{ID: "2", Name: "Samwise Gamgee", Race: "Hobbit", Gender: "Male"},
+	{ID: "3", Name: "Arwen", Race: "Elf", Gender: "Female"},
+	{ID: "4", Name: "Gandalf", Race: "Maiar", Gender: "Male"},
+	{ID: "5", Name: "Galadriel", Race: "Elf", Gender: "Female"},
+	{ID: "6", Name: "Gollum", Race: "Hobbit", Gender: "Male"},
+}
+
+var testMovies = []Movie{
+	{ID: "1", Name: "The Fellowship of the Ring", RuntimeInMinutes: 178, AcademyAwardWins: 4, RottenTomatoesScore: 91},
+	{ID: "2", Name: "The Two Towers", RuntimeInMinutes: 179, AcademyAwardWins: 2, RottenTomatoesScore: 96},
+	{ID: "3", Name: "The Return of the King", RuntimeInMinutes: 201, AcademyAwardWins: 11, RottenTomatoesScore: 95},
+	{ID: "4", Name: "The Hobbit Series", RuntimeInMinutes: 462, AcademyAwardWins: 1, RottenTomatoesScore: 66.33333333},
+}
+
+func ids[T any](page Page[T], id func(T) string) []string {
+	out := []string{}
+	for _, item := range page.Items {
+		out = append(out, id(item))
+	}
+	return out
+}
+
+func characterIDs(page Page[Character]) []string {
+	return ids(page, func(c Character) string { return c.ID })
+}
+
+func movieIDs(page Page[Movie]) []string {
+	return ids(page, func(m Movie) string { return m.ID })
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []RequestOption
+		want []string
+	}{
+		{"no options", nil, []string{"1", "2", "3", "4", "5", "6"}},
+		{"match", []RequestOption{WithFilterMatch("name", "Arwen")}, []string{"3"}},
+		{"match is case sensitive", []RequestOption{WithFilterMatch("name", "arwen")}, []string{}},
+		{"negate", []RequestOption{WithFilterNegate("race", "Hobbit")}, []string{"3", "4", "5"}},
+		{"include", []RequestOption{WithFilterInclude("race", "Elf", "Maiar")}, []string{"3", "4", "5"}},
+		{"exclude", []RequestOption{WithFilterExclude("race", "Elf", "Maiar")}, []string{"1", "2", "6"}},
+		{"regex include", []RequestOption{WithRegexInclude("name", "/^ga/i")}, []string{"4", "5"}},
+		{"regex exclude", []RequestOption{WithRegexExclude("name", "/g/i")}, []string{"3"}},
+		{"multiple conditions", []RequestOption{WithFilterNegate("race", "Elf"), WithFilterNegate("race", "Maiar"), WithFilterMatch("gender", "Male")}, []string{"1", "2", "6"}},
+		{"unknown field", []RequestOption{WithFilterMatch("ring", "One")}, []string{}},
+		{"negated unknown field", []RequestOption{WithFilterNegate("ring", "One"), WithLimit(2)}, []string{"1", "2"}},
+		{"sort asc", []RequestOption{WithSort("name", "asc")}, []string{"3", "1", "5", "4", "6", "2"}},
+		{"sort desc", []RequestOption{WithSort("name", "desc"), WithFilterMatch("race", "Hobbit")}, []string{"2", "6", "1"}},
+		{"sort is stable", []RequestOption{WithSort("race", "asc")}, []string{"3", "5", "1", "2", "6", "4"}},
+		{"limit", []RequestOption{WithLimit(2)}, []string{"1", "2"}},
+		{"page", []RequestOption{WithLimit(4), WithPage(2)}, []string{"5", "6"}},
+		{"offset", []RequestOption{WithLimit(2), WithOffset(3)}, []string{"4", "5"}},
+		{"offset beyond total", []RequestOption{WithOffset(10)}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := Evaluate(testCharacters, tt.opts...)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, characterIDs(page))
+		})
+	}
+}
+
+func TestEvaluate_numbers(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []RequestOption
+		want []string
+	}{
+		{"lt", []RequestOption{WithComparison("runtimeInMinutes", OpLt, 179)}, []string{"1"}},
+		{"lte", []RequestOption{WithComparison("runtimeInMinutes", OpLte, 179)}, []string{"1", "2"}},
+		{"gt", []RequestOption{WithComparison("academyAwardWins", OpGt, 2)}, []string{"1", "3"}},
+		{"gte float", []RequestOption{WithComparison("rottenTomatoesScore", OpGte, 95)}, []string{"2", "3"}},
+		{"eq", []RequestOption{WithComparison("academyAwardWins", OpEq, 11)}, []string{"3"}},
+		{"ne", []RequestOption{WithComparison("academyAwardWins", OpNe, 11)}, []string{"1", "2", "4"}},
+		{"match number", []RequestOption{WithFilterInclude("academyAwardWins", "1", "2")}, []string{"2", "4"}},
+		{"range", []RequestOption{WithComparison("runtimeInMinutes", OpGt, 178), WithComparison("runtimeInMinutes", OpLt, 300)}, []string{"2", "3"}},
+		{"compare text field", []RequestOption{WithComparison("name", OpGt, 0)}, []string{}},
+		{"sort numbers", []RequestOption{WithSort("academyAwardWins", "desc")}, []string{"3", "1", "2", "4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := Evaluate(testMovies, tt.opts...)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, movieIDs(page))
+		})
+	}
+
+	t.Run("pointers", func(t *testing.T) {
+		movies := []*Movie{&testMovies[0], &testMovies[1]}
+		page, err := Evaluate(movies, WithComparison("runtimeInMinutes", OpGt, 178))
+		assert.Nil(t, err)
+		assert.Equal(t, []*Movie{&testMovies[1]}, page.Items)
+	})
+
+	t.Run("query builder", func(t *testing.T) {
+		q := Query(MovieAcademyAwardWins.Gte(2)).SortBy(MovieRuntimeInMinutes, Desc)
+		page, err := Evaluate(testMovies, q.Build()...)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"3", "2", "1"}, movieIDs(page))
+	})
+}
+
+func TestEvaluate_page(t *testing.T) {
+	assert := assert.New(t)
+
+	page, err := Evaluate(testCharacters)
+	assert.Nil(err)
+	assert.Equal(Page[Character]{Items: testCharacters, Total: 6, Limit: 1000, Offset: 0, Page: 1, Pages: 1}, page)
+
+	page, err = Evaluate(testCharacters, WithLimit(4), WithPage(2))
+	assert.Nil(err)
+	assert.Equal(6, page.Total)
+	assert.Equal(4, page.Offset)
+	assert.Equal(2, page.Page)
+	assert.Equal(2, page.Pages)
+	assert.False(page.HasNext())
+
+	page, err = Evaluate(testCharacters, WithLimit(2), WithOffset(3), WithPage(1))
+	assert.Nil(err)
+	assert.Equal(3, page.Offset)
+	assert.Equal(2, page.Page)
+	assert.Equal(3, page.Pages)
+	assert.True(page.HasNext())
+
+	// pages and offsets far beyond the results are empty
+	page, err = Evaluate(testCharacters, WithPage(math.MaxInt64), WithLimit(2))
+	assert.Nil(err)
+	assert.Empty(page.Items)
+	assert.Equal(6, page.Total)
+
+	page, err = Evaluate(testCharacters, WithOffset(math.MaxInt64))
+	assert.Nil(err)
+	assert.Empty(page.Items)
+	assert.Equal(math.MaxInt64, page.Offset)
+
+	page, err = Evaluate(testCharacters, WithOffset(math.MaxInt64), WithLimit(math.MaxInt64))
+	assert.Nil(err)
+	assert.Empty(page.Items)
+
+	fake := NewFakeAPI()
+	SetFakeResource(fake, "character", testCharacters)
+	characters, err := fake.Client().Characters().List(WithPage(math.MaxInt64), WithLimit(2))
+	assert.Nil(err)
+	assert.Empty(characters)
+
+	page, err = Evaluate(testCharacters, WithFilterMatch("race", "Ent"))
+	assert.Nil(err)
+	assert.Equal(Page[Character]{Items: []Character{}, Total: 0, Limit: 1000, Page: 1, Pages: 1}, page)
+}
+
+func TestEvaluate_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  RequestOption
+	}{
+		{"comparison", WithComparison("runtimeInMinutes", "~", 1)},
+		{"limit", WithLimit(0)},
+		{"page", WithPage(-1)},
+		{"offset", WithOffset(-1)},
+		{"sort direction", WithSort("name", "sideways")},
+		{"regex", WithRegexInclude("name", "/(/")},
+		{"regex flags", WithRegexInclude("name", "/a/g")},
+		{"raw comparison", func(req *RequestBuilder) { req.AddFilter("runtimeInMinutes", OpLt, "long") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Evaluate(testMovies, tt.opt)
+			assert.ErrorIs(t, err, ErrInvalidQuery)
+		})
+	}
+}
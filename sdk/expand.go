@@ -0,0 +1,238 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// WithExpand sets the relationships resolved by the ListExpanded and GetExpanded methods,
+// such as the character and movie of a quote. If not provided, every relationship is expanded.
+// Other methods fail with an error matching ErrInvalidQuery if it is provided
+func WithExpand(fields ...string) RequestOption {
+	return func(req *RequestBuilder) {
+		req.expand = append(req.expand, fields...)
+	}
+}
+
+// withoutExpand removes the relationships set by WithExpand once they have been read,
+// so the requests made to expand them are not rejected
+func withoutExpand(req *RequestBuilder) {
+	req.expand = nil
+}
+
+// ExpandedQuote is a Quote with the documents it references resolved.
+// The IDs of the references remain available through the embedded Quote, and are encoded
+// as character and movie, while the resolved documents are encoded as characterDoc and movieDoc
+type ExpandedQuote struct {
+	Quote
+	// Character is the character speaking the quote, or nil if not expanded or not found
+	Character *Character `json:"characterDoc,omitempty"`
+	// Movie is the movie the quote is from, or nil if not expanded or not found
+	Movie *Movie `json:"movieDoc,omitempty"`
+}
+
+// ExpandedChapter is a Chapter with the book it belongs to resolved.
+// The ID of the book remains available through the embedded Chapter and is encoded as book,
+// while the resolved book is encoded as bookDoc
+type ExpandedChapter struct {
+	Chapter
+	// Book is the book containing the chapter, or nil if not expanded or not found
+	Book *Book `json:"bookDoc,omitempty"`
+}
+
+// expansions returns the relationships to expand from those set by WithExpand,
+// or all of them if none were set
+func expansions(path string, resource string, supported []string, opts ...RequestOption) (map[string]bool, error) {
+	fields := applyOptions(opts...).expand
+	if len(fields) == 0 {
+		fields = supported
+	}
+	expand := map[string]bool{}
+	for _, field := range fields {
+		valid := false
+		for _, s := range supported {
+			valid = valid || s == field
+		}
+		if !valid {
+			return nil, SDKError{
+				Kind:     ErrorKindRequest,
+				Method:   http.MethodGet,
+				Endpoint: path,
+				Err:      fmt.Errorf("%w: cannot expand %q of %s", ErrInvalidQuery, field, resource),
+			}
+		}
+		expand[field] = true
+	}
+	return expand, nil
+}
+
+// resolve fetches the resources with the given ids in batches, ignoring those which
+// are empty, invalid or not found. Lookups go through the client's cache if configured
+func resolve[T any](ctx context.Context, r ResourceClient[T], ids []string) (map[string]T, error) {
+	var valid []string
+	for _, id := range ids {
+		if id != "" && validateID(r.path, id) == nil {
+			valid = append(valid, id)
+		}
+	}
+	if len(valid) == 0 {
+		return map[string]T{}, nil
+	}
+	found, errs := r.GetManyContext(ctx, valid, withoutExpand)
+	for _, err := range errs {
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+	return found, nil
+}
+
+// lookup returns a pointer to the resource with the given id, or nil if it was not found
+func lookup[T any](found map[string]T, id string) *T {
+	item, ok := found[id]
+	if !ok {
+		return nil
+	}
+	return &item
+}
+
+// ListExpanded returns quotes with the characters and movies they reference, as set by WithExpand.
+// Referenced documents are fetched with a single request per relationship for each
+// batch of IDs, using the client's cache if configured
+func (q QuotesClient) ListExpanded(opts ...RequestOption) ([]ExpandedQuote, error) {
+	return q.ListExpandedContext(context.Background(), opts...)
+}
+
+// ListExpandedContext is like ListExpanded but uses the provided context for the requests
+func (q QuotesClient) ListExpandedContext(ctx context.Context, opts ...RequestOption) ([]ExpandedQuote, error) {
+	expand, err := expansions(q.path, q.resource, []string{"character", "movie"}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	quotes, err := q.ListContext(ctx, append(opts[:len(opts):len(opts)], withoutExpand)...)
+	if err != nil {
+		return nil, err
+	}
+	return q.expand(ctx, quotes, expand)
+}
+
+// GetExpanded returns a single quote by ID with the characters and movies it references, as set by WithExpand
+func (q QuotesClient) GetExpanded(id string, opts ...RequestOption) (ExpandedQuote, error) {
+	return q.GetExpandedContext(context.Background(), id, opts...)
+}
+
+// GetExpandedContext is like GetExpanded but uses the provided context for the requests
+func (q QuotesClient) GetExpandedContext(ctx context.Context, id string, opts ...RequestOption) (ExpandedQuote, error) {
+	expand, err := expansions(q.path, q.resource, []string{"character", "movie"}, opts...)
+	if err != nil {
+		return ExpandedQuote{}, err
+	}
+	quote, err := q.GetContext(ctx, id, append(opts[:len(opts):len(opts)], withoutExpand)...)
+	if err != nil {
+		return ExpandedQuote{}, err
+	}
+	expanded, err := q.expand(ctx, []Quote{quote}, expand)
+	if err != nil {
+		return ExpandedQuote{}, err
+	}
+	return expanded[0], nil
+}
+
+// expand resolves the relationships of quotes set in expand
+func (q QuotesClient) expand(ctx context.Context, quotes []Quote, expand map[string]bool) ([]ExpandedQuote, error) {
+	var err error
+	characterIDs := make([]string, 0, len(quotes))
+	movieIDs := make([]string, 0, len(quotes))
+	for _, quote := range quotes {
+		characterIDs = append(characterIDs, quote.Character)
+		movieIDs = append(movieIDs, quote.Movie)
+	}
+
+	characters := map[string]Character{}
+	if expand["character"] {
+		if characters, err = resolve(ctx, q.c.Characters().ResourceClient, characterIDs); err != nil {
+			return nil, err
+		}
+	}
+	movies := map[string]Movie{}
+	if expand["movie"] {
+		if movies, err = resolve(ctx, q.c.Movies().ResourceClient, movieIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	expanded := make([]ExpandedQuote, len(quotes))
+	for i, quote := range quotes {
+		expanded[i] = ExpandedQuote{
+			Quote:     quote,
+			Character: lookup(characters, quote.Character),
+			Movie:     lookup(movies, quote.Movie),
+		}
+	}
+	return expanded, nil
+}
+
+// ListExpanded returns chapters with the books they belong to.
+// Books are fetched with a single request for each batch of IDs, using the client's cache if configured
+func (ch ChapterClient) ListExpanded(opts ...RequestOption) ([]ExpandedChapter, error) {
+	return ch.ListExpandedContext(context.Background(), opts...)
+}
+
+// ListExpandedContext is like ListExpanded but uses the provided context for the requests
+func (ch ChapterClient) ListExpandedContext(ctx context.Context, opts ...RequestOption) ([]ExpandedChapter, error) {
+	expand, err := expansions(ch.path, ch.resource, []string{"book"}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	chapters, err := ch.ListContext(ctx, append(opts[:len(opts):len(opts)], withoutExpand)...)
+	if err != nil {
+		return nil, err
+	}
+	return ch.expand(ctx, chapters, expand)
+}
+
+// GetExpanded returns a single chapter by ID with the book it belongs to
+func (ch ChapterClient) GetExpanded(id string, opts ...RequestOption) (ExpandedChapter, error) {
+	return ch.GetExpandedContext(context.Background(), id, opts...)
+}
+
+// GetExpandedContext is like GetExpanded but uses the provided context for the requests
+func (ch ChapterClient) GetExpandedContext(ctx context.Context, id string, opts ...RequestOption) (ExpandedChapter, error) {
+	expand, err := expansions(ch.path, ch.resource, []string{"book"}, opts...)
+	if err != nil {
+		return ExpandedChapter{}, err
+	}
+	chapter, err := ch.GetContext(ctx, id, append(opts[:len(opts):len(opts)], withoutExpand)...)
+	if err != nil {
+		return ExpandedChapter{}, err
+	}
+	expanded, err := ch.expand(ctx, []Chapter{chapter}, expand)
+	if err != nil {
+		return ExpandedChapter{}, err
+	}
+	return expanded[0], nil
+}
+
+// expand resolves the relationships of chapters set in expand
+func (ch ChapterClient) expand(ctx context.Context, chapters []Chapter, expand map[string]bool) ([]ExpandedChapter, error) {
+	var err error
+	bookIDs := make([]string, 0, len(chapters))
+	for _, chapter := range chapters {
+		bookIDs = append(bookIDs, chapter.Book)
+	}
+
+	books := map[string]Book{}
+	if expand["book"] {
+		if books, err = resolve(ctx, ch.c.Books().ResourceClient, bookIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	expanded := make([]ExpandedChapter, len(chapters))
+	for i, chapter := range chapters {
+		expanded[i] = ExpandedChapter{Chapter: chapter, Book: lookup(books, chapter.Book)}
+	}
+	return expanded, nil
+}
@@ -0,0 +1,165 @@
+package sdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpand(t *testing.T) {
+	const (
+		gandalf   = "5cd99d4bde30eff6ebccfc15"
+		unknown   = "5cd99d4bde30eff6ebccffff"
+		towers    = "5cd95395de30eff6ebccde5b"
+		fellow    = "5cd95395de30eff6ebccde5c"
+		book      = "5cf5805fb53e011a64671582"
+		otherBook = "5cf58077b53e011a64671583"
+	)
+	fake := newTestFake()
+	SetFakeResource(fake, "movie", []Movie{
+		{ID: towers, Name: "The Two Towers"},
+		{ID: fellow, Name: "The Fellowship of the Ring"},
+	})
+	SetFakeResource(fake, "quote", []Quote{
+		{ID: "5cd96e05de30eff6ebcce7e9", Character: testID, Movie: fellow, Dialog: "I will take it!"},
+		{ID: "5cd96e05de30eff6ebcce7ea", Character: gandalf, Movie: fellow, Dialog: "You shall not pass!"},
+		{ID: "5cd96e05de30eff6ebcce7eb", Character: unknown, Movie: towers, Dialog: "Po-tay-toes"},
+	})
+	SetFakeResource(fake, "book", []Book{{ID: book, Name: "The Fellowship Of The Ring"}})
+	SetFakeResource(fake, "chapter", []Chapter{
+		{ID: "6091b6d6d58360f988133b8b", Name: "A Long-expected Party", Book: book},
+		{ID: "6091b6d6d58360f988133b8c", Name: "The Shadow of the Past", Book: book},
+		{ID: "6091b6d6d58360f988133b8d", Name: "Missing", Book: otherBook},
+	})
+
+	var requests int32
+	client := NewWithConfig(ClientConfig{
+		Client:  &http.Client{Transport: fake},
+		BaseURL: "http://fake.the-one-api.dev/v2",
+		ApiKey:  "fake",
+		Middleware: []Middleware{func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&requests, 1)
+				return next.Do(req)
+			})
+		}},
+	})
+
+	t.Run("quotes", func(t *testing.T) {
+		assert := assert.New(t)
+		atomic.StoreInt32(&requests, 0)
+		quotes, err := client.Quotes().ListExpanded()
+		assert.Nil(err)
+		// one request for the quotes and one for each relationship
+		assert.Equal(int32(3), atomic.LoadInt32(&requests))
+		assert.Len(quotes, 3)
+
+		assert.Equal("I will take it!", quotes[0].Dialog)
+		assert.Equal(testID, quotes[0].Quote.Character)
+		assert.Equal("Frodo Baggins", quotes[0].Character.Name)
+		assert.Equal("The Fellowship of the Ring", quotes[0].Movie.Name)
+		assert.Equal("Gandalf", quotes[1].Character.Name)
+		// missing references are left unresolved
+		assert.Nil(quotes[2].Character)
+		assert.Equal("The Two Towers", quotes[2].Movie.Name)
+	})
+
+	t.Run("selected relationships", func(t *testing.T) {
+		assert := assert.New(t)
+		atomic.StoreInt32(&requests, 0)
+		quote, err := client.Quotes().GetExpanded("5cd96e05de30eff6ebcce7ea", WithExpand("character"))
+		assert.Nil(err)
+		assert.Equal(int32(2), atomic.LoadInt32(&requests))
+		assert.Equal("Gandalf", quote.Character.Name)
+		assert.Nil(quote.Movie)
+		assert.Equal(fellow, quote.Quote.Movie)
+
+		_, err = client.Quotes().ListExpanded(WithExpand("book"))
+		assert.ErrorIs(err, ErrInvalidQuery)
+		assert.ErrorIs(err, SDKError{Kind: ErrorKindRequest})
+
+		// other methods reject the option rather than ignoring it
+		_, err = client.Quotes().List(WithExpand("character"))
+		assert.ErrorIs(err, ErrInvalidQuery)
+		assert.ErrorIs(err, SDKError{Kind: ErrorKindRequest})
+	})
+
+	t.Run("chapters", func(t *testing.T) {
+		assert := assert.New(t)
+		atomic.StoreInt32(&requests, 0)
+		chapters, err := client.Chapters().ListExpanded(WithLimit(2))
+		assert.Nil(err)
+		assert.Equal(int32(2), atomic.LoadInt32(&requests))
+		assert.Len(chapters, 2)
+		for _, chapter := range chapters {
+			assert.Equal("The Fellowship Of The Ring", chapter.Book.Name)
+		}
+
+		chapter, err := client.Chapters().GetExpanded("6091b6d6d58360f988133b8d")
+		assert.Nil(err)
+		assert.Equal("Missing", chapter.Name)
+		assert.Nil(chapter.Book)
+	})
+
+	t.Run("failed lookup", func(t *testing.T) {
+		broken := NewWithConfig(ClientConfig{
+			Client:  &http.Client{Transport: fake},
+			BaseURL: "http://fake.the-one-api.dev/v2",
+			ApiKey:  "fake",
+			Middleware: []Middleware{func(next Doer) Doer {
+				return DoerFunc(func(req *http.Request) (*http.Response, error) {
+					if strings.HasSuffix(req.URL.Path, "/movie") {
+						return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody, Request: req}, nil
+					}
+					return next.Do(req)
+				})
+			}},
+		})
+		_, err := broken.Quotes().ListExpanded()
+		assert.ErrorIs(t, err, ErrServer)
+	})
+}
+
+func TestExpanded_json(t *testing.T) {
+	assert := assert.New(t)
+
+	quote := ExpandedQuote{
+		Quote:     Quote{ID: "5cd96e05de30eff6ebcce7e9", Character: testID, Movie: "5cd95395de30eff6ebccde5c", Dialog: "I will take it!"},
+		Character: &Character{ID: testID, Name: "Frodo Baggins"},
+	}
+	data, err := json.Marshal(quote)
+	assert.Nil(err)
+	assert.Contains(string(data), `"character":"`+testID+`"`)
+	assert.Contains(string(data), `"movie":"5cd95395de30eff6ebccde5c"`)
+	assert.Contains(string(data), `"characterDoc":{`)
+	assert.NotContains(string(data), `"movieDoc"`)
+
+	var decoded ExpandedQuote
+	assert.Nil(json.Unmarshal(data, &decoded))
+	assert.Equal(quote, decoded)
+
+	// documents shaped as returned by the API decode with their references unresolved
+	decoded = ExpandedQuote{}
+	assert.Nil(json.Unmarshal([]byte(`{"_id": "5cd96e05de30eff6ebcce7e9", "character": "`+testID+`", "movie": "5cd95395de30eff6ebccde5c"}`), &decoded))
+	assert.Equal(testID, decoded.Quote.Character)
+	assert.Nil(decoded.Character)
+
+	chapter := ExpandedChapter{
+		Chapter: Chapter{ID: "6091b6d6d58360f988133b8b", Name: "A Long-expected Party", Book: "5cf5805fb53e011a64671582"},
+		Book:    &Book{ID: "5cf5805fb53e011a64671582", Name: "The Fellowship Of The Ring"},
+	}
+	data, err = json.Marshal(chapter)
+	assert.Nil(err)
+	assert.Contains(string(data), `"book":"5cf5805fb53e011a64671582"`)
+	var decodedChapter ExpandedChapter
+	assert.Nil(json.Unmarshal(data, &decodedChapter))
+	assert.Equal(chapter, decodedChapter)
+
+	data, err = json.Marshal(ExpandedChapter{Chapter: chapter.Chapter})
+	assert.Nil(err)
+	assert.Contains(string(data), `"book":"5cf5805fb53e011a64671582"`)
+}
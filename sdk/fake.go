@@ -0,0 +1,134 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// FakeAPI is an in-memory implementation of The One API, serving resources with the same filtering,
+// sorting, and pagination as Evaluate. It allows code using a OneAPIClient to be tested without network access
+//
+//	fake := sdk.NewFakeAPI()
+//	sdk.SetFakeResource(fake, "character", characters)
+//	sdk.SetFakeResource(fake, "quote", quotes)
+//	client := fake.Client()
+//
+// FakeAPI is both an http.Handler, allowing it to be served by an httptest.Server,
+// and an http.RoundTripper, allowing it to be used as the Transport of an http.Client
+type FakeAPI struct {
+	mu        sync.RWMutex
+	resources map[string]fakeResource
+}
+
+// fakeResource evaluates a query against the resources of a single type,
+// returning the response to be encoded
+type fakeResource func(q queryString) (interface{}, error)
+
+// NewFakeAPI creates a FakeAPI without any resources
+func NewFakeAPI() *FakeAPI {
+	return &FakeAPI{resources: map[string]fakeResource{}}
+}
+
+// SetFakeResource sets the resources served by f at /{resource}, replacing any previously set.
+// A single resource is served at /{resource}/{id} using the _id field of T.
+// Nested listings such as /character/{id}/quote serve the resources whose field named
+// after the parent resource, here character, equals the id
+func SetFakeResource[T any](f *FakeAPI, resource string, items []T) {
+	items = append([]T(nil), items...)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resources[strings.Trim(resource, "/")] = func(q queryString) (interface{}, error) {
+		page, err := evaluate(items, q)
+		if err != nil {
+			return nil, err
+		}
+		return listResponse[T]{
+			paginatedResponse: paginatedResponse{
+				Total:  page.Total,
+				Limit:  page.Limit,
+				Offset: page.Offset,
+				Page:   page.Page,
+				Pages:  page.Pages,
+			},
+			Docs: page.Items,
+		}, nil
+	}
+}
+
+// Client returns a OneAPIClient whose requests are served by f
+func (f *FakeAPI) Client() OneAPIClient {
+	return NewWithConfig(ClientConfig{
+		Client:  &http.Client{Transport: f},
+		BaseURL: "http://fake.the-one-api.dev/v2",
+		ApiKey:  "fake",
+	})
+}
+
+// RoundTrip serves req without making a network request
+func (f *FakeAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	rec := httptest.NewRecorder()
+	f.ServeHTTP(rec, req)
+	resp := rec.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+// ServeHTTP serves the resources of f, routing requests as the API does
+func (f *FakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	// ignore the version prefix of the default base URL
+	if len(segments) > 0 && segments[0] == "v2" {
+		segments = segments[1:]
+	}
+
+	q, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		writeFakeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var resource string
+	switch len(segments) {
+	case 1:
+		resource = segments[0]
+	case 2:
+		resource = segments[0]
+		q = append(queryString{{key: "_id", op: OpEq, val: segments[1]}}, q...)
+	case 3:
+		resource = segments[2]
+		q = append(queryString{{key: segments[0], op: OpEq, val: segments[1]}}, q...)
+	default:
+		writeFakeError(w, http.StatusNotFound, errors.New("Not found"))
+		return
+	}
+
+	f.mu.RLock()
+	serve, ok := f.resources[resource]
+	f.mu.RUnlock()
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, errors.New("Not found"))
+		return
+	}
+
+	resp, err := serve(q)
+	if err != nil {
+		writeFakeError(w, http.StatusBadRequest, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func writeFakeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(APIError{Success: false, Message: fmt.Sprint(err)})
+}
@@ -0,0 +1,91 @@
+package sdk
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFake() *FakeAPI {
+	fake := NewFakeAPI()
+	SetFakeResource(fake, "character", []Character{
+		{ID: testID, Name: "Frodo Baggins", Race: "Hobbit"},
+		{ID: "5cd99d4bde30eff6ebccfc15", Name: "Gandalf", Race: "Maiar"},
+	})
+	SetFakeResource(fake, "quote", []Quote{
+		{ID: "5cd96e05de30eff6ebcce7e9", Character: testID, Dialog: "I will take it!"},
+		{ID: "5cd96e05de30eff6ebcce7ea", Character: "5cd99d4bde30eff6ebccfc15", Dialog: "You shall not pass!"},
+		{ID: "5cd96e05de30eff6ebcce7eb", Character: testID, Dialog: "I wish the ring had never come to me."},
+	})
+	return fake
+}
+
+func TestFakeAPI(t *testing.T) {
+	assert := assert.New(t)
+	client := newTestFake().Client()
+
+	characters, err := client.Characters().List(WithFilterNegate("race", "Maiar"))
+	assert.Nil(err)
+	assert.Len(characters, 1)
+	assert.Equal("Frodo Baggins", characters[0].Name)
+
+	character, err := client.Characters().Get(testID)
+	assert.Nil(err)
+	assert.Equal("Frodo Baggins", character.Name)
+
+	_, err = client.Characters().Get("5cd99d4bde30eff6ebccffff")
+	assert.ErrorIs(err, ErrNotFound)
+
+	quotes, err := client.Characters().GetQuotes(testID, WithSort("dialog", "asc"))
+	assert.Nil(err)
+	assert.Len(quotes, 2)
+	assert.Equal("I will take it!", quotes[0].Dialog)
+
+	count, err := client.Quotes().Count(WithRegexInclude("dialog", "/ring/"))
+	assert.Nil(err)
+	assert.Equal(1, count)
+
+	all, err := client.Quotes().Iter(WithLimit(1)).All()
+	assert.Nil(err)
+	assert.Len(all, 3)
+
+	_, err = client.Movies().List()
+	assert.ErrorIs(err, ErrNotFound)
+
+	_, err = client.Quotes().List(WithSort("dialog", "sideways"))
+	assert.ErrorIs(err, ErrBadRequest)
+}
+
+func TestFakeAPI_matchesEvaluate(t *testing.T) {
+	fake := NewFakeAPI()
+	SetFakeResource(fake, "movie", testMovies)
+	client := fake.Client()
+
+	queries := [][]RequestOption{
+		{WithComparison("runtimeInMinutes", OpGte, 179), WithSort("name", "desc")},
+		{WithFilterExclude("name", "The Two Towers"), WithLimit(2), WithPage(2)},
+		{WithRegexInclude("name", "/the/i"), WithOffset(1)},
+	}
+	for _, opts := range queries {
+		want, err := Evaluate(testMovies, opts...)
+		assert.Nil(t, err)
+		got, err := client.Movies().ListPage(opts...)
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestFakeAPI_server(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(newTestFake())
+	defer server.Close()
+	client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key"})
+
+	page, err := client.Quotes().ListPageContext(context.Background(), WithLimit(2))
+	assert.Nil(err)
+	assert.Len(page.Items, 2)
+	assert.Equal(3, page.Total)
+	assert.Equal(2, page.Pages)
+}
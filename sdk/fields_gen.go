@@ -0,0 +1,81 @@
+// Code generated by fieldgen. DO NOT EDIT.
+
+package sdk
+
+// Fields of Book which may be used to build queries
+const (
+	// BookID filters on Book.ID
+	BookID StringField = "_id"
+	// BookName filters on Book.Name
+	BookName StringField = "name"
+)
+
+// Fields of Movie which may be used to build queries
+const (
+	// MovieID filters on Movie.ID
+	MovieID StringField = "_id"
+	// MovieName filters on Movie.Name
+	MovieName StringField = "name"
+	// MovieRuntimeInMinutes filters on Movie.RuntimeInMinutes
+	MovieRuntimeInMinutes NumberField = "runtimeInMinutes"
+	// MovieBudgetInMillions filters on Movie.BudgetInMillions
+	MovieBudgetInMillions NumberField = "budgetInMillions"
+	// MovieBoxOfficeRevenueInMillions filters on Movie.BoxOfficeRevenueInMillions
+	MovieBoxOfficeRevenueInMillions NumberField = "boxOfficeRevenueInMillions"
+	// MovieAcademyAwardNominations filters on Movie.AcademyAwardNominations
+	MovieAcademyAwardNominations NumberField = "academyAwardNominations"
+	// MovieAcademyAwardWins filters on Movie.AcademyAwardWins
+	MovieAcademyAwardWins NumberField = "academyAwardWins"
+	// MovieRottenTomatoesScore filters on Movie.RottenTomatoesScore
+	MovieRottenTomatoesScore NumberField = "rottenTomatoesScore"
+)
+
+// Fields of Character which may be used to build queries
+const (
+	// CharacterID filters on Character.ID
+	CharacterID StringField = "_id"
+	// CharacterBirth filters on Character.Birth
+	CharacterBirth StringField = "birth"
+	// CharacterDeath filters on Character.Death
+	CharacterDeath StringField = "death"
+	// CharacterGender filters on Character.Gender
+	CharacterGender StringField = "gender"
+	// CharacterHeight filters on Character.Height
+	CharacterHeight StringField = "height"
+	// CharacterRealm filters on Character.Realm
+	CharacterRealm StringField = "realm"
+	// CharacterSpouse filters on Character.Spouse
+	CharacterSpouse StringField = "spouse"
+	// CharacterName filters on Character.Name
+	CharacterName StringField = "name"
+	// CharacterRace filters on Character.Race
+	CharacterRace StringField = "race"
+	// CharacterHair filters on Character.Hair
+	CharacterHair StringField = "hair"
+	// CharacterWikiUrl filters on Character.WikiUrl
+	CharacterWikiUrl StringField = "wikiUrl"
+)
+
+// Fields of Quote which may be used to build queries
+const (
+	// QuoteID filters on Quote.ID
+	QuoteID StringField = "_id"
+	// QuoteQuoteID filters on Quote.QuoteID
+	QuoteQuoteID StringField = "id"
+	// QuoteCharacter filters on Quote.Character
+	QuoteCharacter StringField = "character"
+	// QuoteMovie filters on Quote.Movie
+	QuoteMovie StringField = "movie"
+	// QuoteDialog filters on Quote.Dialog
+	QuoteDialog StringField = "dialog"
+)
+
+// Fields of Chapter which may be used to build queries
+const (
+	// ChapterID filters on Chapter.ID
+	ChapterID StringField = "_id"
+	// ChapterName filters on Chapter.Name
+	ChapterName StringField = "chapterName"
+	// ChapterBook filters on Chapter.Book
+	ChapterBook StringField = "book"
+)
@@ -0,0 +1,130 @@
+// Command fieldgen generates the typed field constants used by the query builder
+// from the fields of the SDK's resource structs.
+//
+// Usage:
+//
+//	fieldgen -output fields_gen.go -types Book,Movie book.go movie.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"strings"
+)
+
+// fieldTypes maps the Go types of struct fields to the field type of the query builder
+var fieldTypes = map[string]string{
+	"string":  "StringField",
+	"int":     "NumberField",
+	"int32":   "NumberField",
+	"int64":   "NumberField",
+	"float32": "NumberField",
+	"float64": "NumberField",
+}
+
+type field struct {
+	constName string
+	goName    string
+	apiName   string
+	fieldType string
+}
+
+func main() {
+	output := flag.String("output", "fields_gen.go", "file to write generated constants to")
+	types := flag.String("types", "", "comma separated list of struct types to generate fields for")
+	flag.Parse()
+
+	structs := map[string]*ast.StructType{}
+	fset := token.NewFileSet()
+	pkg := ""
+	for _, path := range flag.Args() {
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			log.Fatal(err)
+		}
+		pkg = f.Name.Name
+		ast.Inspect(f, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.TypeSpec); ok {
+				if st, ok := spec.Type.(*ast.StructType); ok {
+					structs[spec.Name.Name] = st
+				}
+			}
+			return true
+		})
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by fieldgen. DO NOT EDIT.\n\npackage %s\n", pkg)
+
+	for _, name := range strings.Split(*types, ",") {
+		st, ok := structs[name]
+		if !ok {
+			log.Fatalf("struct %s not found", name)
+		}
+		fields := structFields(name, st)
+		if len(fields) == 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, "\n// Fields of %s which may be used to build queries\nconst (\n", name)
+		for _, f := range fields {
+			fmt.Fprintf(&buf, "\t// %s filters on %s.%s\n", f.constName, name, f.goName)
+			fmt.Fprintf(&buf, "\t%s %s = %q\n", f.constName, f.fieldType, f.apiName)
+		}
+		fmt.Fprintf(&buf, ")\n")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func structFields(typeName string, st *ast.StructType) []field {
+	var fields []field
+	for _, f := range st.Fields.List {
+		ident, ok := f.Type.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		fieldType, ok := fieldTypes[ident.Name]
+		if !ok {
+			continue
+		}
+
+		tag := ""
+		if f.Tag != nil {
+			tag = reflect.StructTag(strings.Trim(f.Tag.Value, "`")).Get("json")
+		}
+		if tag == "-" {
+			continue
+		}
+
+		for _, name := range f.Names {
+			if !name.IsExported() {
+				continue
+			}
+			apiName := strings.Split(tag, ",")[0]
+			if apiName == "" {
+				apiName = name.Name
+			}
+			fields = append(fields, field{
+				constName: typeName + name.Name,
+				goName:    name.Name,
+				apiName:   apiName,
+				fieldType: fieldType,
+			})
+		}
+	}
+	return fields
+}
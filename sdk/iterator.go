@@ -0,0 +1,143 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// pageFetcher retrieves a single page of resources
+type pageFetcher[T any] func(ctx context.Context, opts ...RequestOption) (Page[T], error)
+
+// Iterator lazily walks all pages of a resource listing, requesting the next page only once the
+// items of the current page have been consumed.
+//
+//	it := client.Quotes().Iter(sdk.WithLimit(100))
+//	for it.Next() {
+//		fmt.Println(it.Value().Dialog)
+//	}
+//	if err := it.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// The iterator controls the page query parameter, so any WithPage option provided is overridden.
+// As an offset takes precedence over the page, options setting an offset fail iteration with an
+// error matching ErrInvalidQuery. Iteration stops at the first error, which is then available from Err.
+type Iterator[T any] struct {
+	ctx   context.Context
+	path  string
+	fetch pageFetcher[T]
+	opts  []RequestOption
+
+	page  int
+	items []T
+	idx   int
+	cur   T
+	meta  Page[T]
+	err   error
+	done  bool
+}
+
+func newIterator[T any](ctx context.Context, path string, fetch pageFetcher[T], opts ...RequestOption) *Iterator[T] {
+	return &Iterator[T]{
+		ctx:   ctx,
+		path:  path,
+		fetch: fetch,
+		opts:  opts,
+	}
+}
+
+// Next advances the iterator to the next resource, fetching the next page if needed.
+// It returns false when all pages have been consumed or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for it.idx >= len(it.items) {
+		if it.done || !it.fetchNext() {
+			return false
+		}
+	}
+	it.cur = it.items[it.idx]
+	it.idx++
+	return true
+}
+
+func (it *Iterator[T]) fetchNext() bool {
+	if it.page == 0 {
+		for _, p := range applyOptions(it.opts...).query {
+			if p.key == "offset" {
+				it.err = SDKError{
+					Kind:     ErrorKindRequest,
+					Method:   http.MethodGet,
+					Endpoint: it.path,
+					Err:      fmt.Errorf("%w: an iterator cannot start at an offset, which would override its page", ErrInvalidQuery),
+				}
+				return false
+			}
+		}
+	}
+	it.page++
+
+	opts := make([]RequestOption, 0, len(it.opts)+1)
+	opts = append(opts, it.opts...)
+	opts = append(opts, WithPage(it.page))
+
+	page, err := it.fetch(it.ctx, opts...)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	// the page did not advance, such as when an offset was set by the client's persistent options,
+	// so its items have already been returned
+	if it.page > 1 && page.Page > 0 && page.Page <= it.meta.Page {
+		it.items, it.idx, it.done = nil, 0, true
+		return true
+	}
+	it.items = page.Items
+	it.idx = 0
+	it.meta = page
+
+	// when the API does not provide the number of pages
+	// keep going until an empty page is returned
+	if len(page.Items) == 0 || (page.Pages > 0 && !page.HasNext()) {
+		it.done = true
+	}
+	return true
+}
+
+// Value returns the current resource
+func (it *Iterator[T]) Value() T {
+	return it.cur
+}
+
+// Err returns the error that stopped iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Page returns the number of the most recently fetched page
+func (it *Iterator[T]) Page() int {
+	return it.page
+}
+
+// Total returns the total number of resources reported by the API.
+// It is zero until the first page has been fetched.
+func (it *Iterator[T]) Total() int {
+	return it.meta.Total
+}
+
+// Pages returns the total number of pages reported by the API.
+// It is zero until the first page has been fetched.
+func (it *Iterator[T]) Pages() int {
+	return it.meta.Pages
+}
+
+// All consumes the remaining pages of the iterator and returns their resources
+func (it *Iterator[T]) All() ([]T, error) {
+	var all []T
+	for it.Next() {
+		all = append(all, it.Value())
+	}
+	return all, it.Err()
+}
@@ -0,0 +1,120 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterator(t *testing.T) {
+	assert := assert.New(t)
+
+	quotes := []Quote{}
+	for i := 0; i < 7; i++ {
+		quotes = append(quotes, Quote{ID: fmt.Sprint(i), Dialog: fmt.Sprintf("dialog %d", i)})
+	}
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Query().Get("page") == r.Header.Get("X-Fail-Page") {
+			w.Write([]byte(`{"success": false, "message": "fail"}`))
+			return
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+		start := (page - 1) * limit
+		end := start + limit
+		if start > len(quotes) {
+			start = len(quotes)
+		}
+		if end > len(quotes) {
+			end = len(quotes)
+		}
+		pages := (len(quotes) + limit - 1) / limit
+		resp := quoteResponse{
+			paginatedResponse: paginatedResponse{Total: len(quotes), Limit: limit, Page: page, Pages: pages},
+			Docs:              quotes[start:end],
+		}
+		data, err := json.Marshal(resp)
+		assert.Nil(err)
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key"})
+
+	t.Run("all pages", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		it := client.Quotes().Iter(WithLimit(3))
+		assert.Equal(0, it.Total())
+
+		got := []Quote{}
+		for it.Next() {
+			got = append(got, it.Value())
+			assert.Equal(7, it.Total())
+			assert.Equal(3, it.Pages())
+		}
+		assert.Nil(it.Err())
+		assert.Equal(quotes, got)
+		assert.Equal(int32(3), atomic.LoadInt32(&requests))
+		assert.False(it.Next())
+	})
+
+	t.Run("lazy", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		it := client.Quotes().Iter(WithLimit(3))
+		assert.Equal(int32(0), atomic.LoadInt32(&requests))
+		assert.True(it.Next())
+		assert.Equal(int32(1), atomic.LoadInt32(&requests))
+		assert.Equal(1, it.Page())
+	})
+
+	t.Run("page option is overridden", func(t *testing.T) {
+		got, err := client.Quotes().Iter(WithLimit(5), WithPage(2)).All()
+		assert.Nil(err)
+		assert.Equal(quotes, got)
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		fail := func(req *RequestBuilder) {
+			req.Header.Set("X-Fail-Page", "2")
+		}
+		it := client.Quotes().Iter(WithLimit(3), fail)
+		got, err := it.All()
+		assert.NotNil(err)
+		assert.Equal(quotes[:3], got)
+		assert.False(it.Next())
+	})
+}
+
+func TestIterator_offset(t *testing.T) {
+	assert := assert.New(t)
+	fake := NewFakeAPI()
+	SetFakeResource(fake, "book", []Book{{ID: "a"}, {ID: "b"}, {ID: "c"}})
+
+	it := fake.Client().Books().Iter(WithOffset(0), WithLimit(1))
+	assert.False(it.Next())
+	assert.ErrorIs(it.Err(), ErrInvalidQuery)
+	assert.ErrorIs(it.Err(), SDKError{Kind: ErrorKindRequest, Endpoint: "/book"})
+
+	_, err := fake.Client().Books().Iter(WithPagination(PaginationOptions{Offset: 1, Limit: 1})).All()
+	assert.ErrorIs(err, ErrInvalidQuery)
+
+	// an offset set for every request stops iteration once the page no longer advances
+	client := NewWithConfig(ClientConfig{
+		Client:            &http.Client{Transport: fake},
+		BaseURL:           "http://fake.the-one-api.dev/v2",
+		PersistentOptions: []RequestOption{WithOffset(1)},
+	})
+	books, err := client.Books().Iter(WithLimit(1)).All()
+	assert.Nil(err)
+	assert.Equal([]Book{{ID: "b"}}, books)
+}
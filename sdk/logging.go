@@ -0,0 +1,54 @@
+package sdk
+
+import (
+	"net/http"
+)
+
+// maxLoggedBody is the number of bytes of a response body included in log events
+const maxLoggedBody = 4096
+
+// Logger receives structured log events from the client, with args being alternating keys and values.
+// It is satisfied by *slog.Logger, as well as adapters for other structured logging libraries
+//
+//	client := sdk.NewWithConfig(sdk.ClientConfig{Logger: slog.Default()})
+//
+// The client logs the start of each request and any cache lookups at debug level,
+// completed requests at info level, retries at warn level, and failed requests at error level
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, args ...interface{}) {}
+func (nopLogger) Info(msg string, args ...interface{})  {}
+func (nopLogger) Warn(msg string, args ...interface{})  {}
+func (nopLogger) Error(msg string, args ...interface{}) {}
+
+// log returns the client's logger, which discards events if none was configured
+func (c OneAPIClient) log() Logger {
+	if c.logger == nil {
+		return nopLogger{}
+	}
+	return c.logger
+}
+
+// redactHeaders returns a copy of h with the value of the Authorization header removed
+func redactHeaders(h http.Header) http.Header {
+	redacted := h.Clone()
+	if redacted.Get("Authorization") != "" {
+		redacted.Set("Authorization", "REDACTED")
+	}
+	return redacted
+}
+
+// loggedBody returns the beginning of a response body to include in log events
+func loggedBody(body []byte) string {
+	if len(body) > maxLoggedBody {
+		return string(body[:maxLoggedBody]) + "..."
+	}
+	return string(body)
+}
@@ -0,0 +1,143 @@
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type logEvent struct {
+	level string
+	msg   string
+	attrs map[string]interface{}
+}
+
+// recordingLogger is a Logger keeping every event in memory
+type recordingLogger struct {
+	mu     sync.Mutex
+	events []logEvent
+}
+
+func (l *recordingLogger) record(level, msg string, args []interface{}) {
+	attrs := map[string]interface{}{}
+	for i := 0; i+1 < len(args); i += 2 {
+		attrs[fmt.Sprint(args[i])] = args[i+1]
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, logEvent{level: level, msg: msg, attrs: attrs})
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) { l.record("DEBUG", msg, args) }
+func (l *recordingLogger) Info(msg string, args ...interface{})  { l.record("INFO", msg, args) }
+func (l *recordingLogger) Warn(msg string, args ...interface{})  { l.record("WARN", msg, args) }
+func (l *recordingLogger) Error(msg string, args ...interface{}) { l.record("ERROR", msg, args) }
+
+func (l *recordingLogger) messages() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var msgs []string
+	for _, e := range l.events {
+		msgs = append(msgs, e.level+" "+e.msg)
+	}
+	return msgs
+}
+
+func (l *recordingLogger) find(msg string) logEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range l.events {
+		if e.msg == msg {
+			return e
+		}
+	}
+	return logEvent{}
+}
+
+func TestLogging(t *testing.T) {
+	assert := assert.New(t)
+
+	var fail int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&fail, -1) >= 0 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if r.URL.Path == "/broken" {
+			w.Write([]byte(`{"docs": "not a list"}`))
+			return
+		}
+		w.Write([]byte(`{"docs": [{"name": "The Two Towers"}]}`))
+	}))
+	defer server.Close()
+
+	t.Run("request events", func(t *testing.T) {
+		logger := &recordingLogger{}
+		client := NewWithConfig(ClientConfig{
+			BaseURL: server.URL,
+			ApiKey:  "secret",
+			Logger:  logger,
+			Retry:   &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+			Cache:   &CachePolicy{Store: NewLRUCache(10)},
+		})
+		atomic.StoreInt32(&fail, 1)
+		_, err := client.Books().List()
+		assert.Nil(err)
+		_, err = client.Books().List()
+		assert.Nil(err)
+
+		assert.Equal([]string{
+			"DEBUG request started",
+			"DEBUG cache lookup",
+			"WARN retrying request",
+			"INFO request completed",
+			"DEBUG request started",
+			"DEBUG cache lookup",
+		}, logger.messages())
+
+		started := logger.find("request started")
+		assert.Equal("/book", started.attrs["endpoint"])
+		headers := started.attrs["headers"].(http.Header)
+		assert.Equal("REDACTED", headers.Get("Authorization"))
+		assert.NotContains(fmt.Sprint(logger.events), "secret")
+
+		retry := logger.find("retrying request")
+		assert.Equal(1, retry.attrs["attempt"])
+		assert.Equal(http.StatusBadGateway, retry.attrs["status"])
+
+		completed := logger.find("request completed")
+		assert.Equal(http.StatusOK, completed.attrs["status"])
+		assert.Equal(2, completed.attrs["attempts"])
+		assert.Greater(completed.attrs["latency"], time.Duration(0))
+		assert.Equal(38, completed.attrs["bytes"])
+
+		assert.Equal(true, logger.events[5].attrs["hit"])
+	})
+
+	t.Run("decode failures", func(t *testing.T) {
+		logger := &recordingLogger{}
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, Logger: logger, LogBodies: true})
+		_, err := NewResourceClient[Book](client, "broken").List()
+		assert.ErrorIs(err, SDKError{Kind: ErrorKindDeserialization})
+
+		assert.Equal([]string{"DEBUG request started", "DEBUG response body", "ERROR response decode failed"}, logger.messages())
+		assert.Equal(`{"docs": "not a list"}`, logger.find("response body").attrs["body"])
+		failed := logger.find("response decode failed")
+		assert.Equal("Deserialization Error", failed.attrs["kind"])
+		assert.NotNil(failed.attrs["error"])
+	})
+
+	t.Run("bodies are not logged by default", func(t *testing.T) {
+		logger := &recordingLogger{}
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, Logger: logger})
+		_, err := client.Books().List()
+		assert.Nil(err)
+		assert.Equal(logEvent{}, logger.find("response body"))
+	})
+}
@@ -0,0 +1,128 @@
+package sdk
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+	"time"
+)
+
+// Doer sends an HTTP request and returns its response, as implemented by *http.Client
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc allows using an ordinary function as a Doer
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req)
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer sending each request, allowing requests, responses,
+// timings and errors to be observed or modified.
+// Each attempt of a retried request passes through the middleware,
+// which may modify the request as it is a copy made for the attempt
+//
+//	func Audit(next sdk.Doer) sdk.Doer {
+//		return sdk.DoerFunc(func(req *http.Request) (*http.Response, error) {
+//			resp, err := next.Do(req)
+//			audit.Record(req.URL.Path, err)
+//			return resp, err
+//		})
+//	}
+type Middleware func(next Doer) Doer
+
+// chain wraps d in middleware, with the first middleware being the outermost
+func chain(d Doer, middleware []Middleware) Doer {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		d = middleware[i](d)
+	}
+	return d
+}
+
+// LoggingMiddleware logs the method, URL, status and duration of each request to logger.
+// If logger is nil, the standard logger is used
+func LoggingMiddleware(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+			elapsed := time.Since(start)
+			if err != nil {
+				logger.Printf("%s %s failed after %s: %v", req.Method, req.URL, elapsed, err)
+				return resp, err
+			}
+			logger.Printf("%s %s %d %s", req.Method, req.URL, resp.StatusCode, elapsed)
+			return resp, err
+		})
+	}
+}
+
+// HeaderMiddleware sets the provided headers on every request, replacing any existing values
+func HeaderMiddleware(headers http.Header) Middleware {
+	return HeaderFuncMiddleware(func(req *http.Request) http.Header {
+		return headers
+	})
+}
+
+// HeaderFuncMiddleware sets the headers returned by fn on every request, such as a
+// correlation ID stored in the request's context
+func HeaderFuncMiddleware(fn func(req *http.Request) http.Header) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			for name, values := range fn(req) {
+				req.Header.Del(name)
+				for _, v := range values {
+					req.Header.Add(name, v)
+				}
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// DumpMiddleware writes each request and response to w in their HTTP wire format,
+// with the Authorization header redacted. Response bodies are only included if body is true
+func DumpMiddleware(w io.Writer, body bool) Middleware {
+	var mu sync.Mutex
+	write := func(dump []byte, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			fmt.Fprintf(w, "dump failed: %v\n", err)
+			return
+		}
+		w.Write(dump)
+		fmt.Fprintln(w)
+	}
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			write(httputil.DumpRequestOut(redactRequest(req), false))
+			resp, err := next.Do(req)
+			if err != nil {
+				write(nil, err)
+				return resp, err
+			}
+			write(httputil.DumpResponse(resp, body))
+			return resp, err
+		})
+	}
+}
+
+// redactRequest returns a copy of req without the value of the Authorization header
+func redactRequest(req *http.Request) *http.Request {
+	if req.Header.Get("Authorization") == "" {
+		return req
+	}
+	redacted := req.Clone(req.Context())
+	redacted.Header = redactHeaders(req.Header)
+	return redacted
+}
@@ -0,0 +1,147 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type correlationKey struct{}
+
+func TestMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		mu      sync.Mutex
+		headers []http.Header
+		fail    int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = append(headers, r.Header.Clone())
+		mu.Unlock()
+		if atomic.AddInt32(&fail, -1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"docs": [{"name": "The Hobbit"}]}`))
+	}))
+	defer server.Close()
+
+	t.Run("order", func(t *testing.T) {
+		var calls []string
+		trace := func(name string) Middleware {
+			return func(next Doer) Doer {
+				return DoerFunc(func(req *http.Request) (*http.Response, error) {
+					calls = append(calls, name+" before")
+					resp, err := next.Do(req)
+					calls = append(calls, name+" after")
+					return resp, err
+				})
+			}
+		}
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, Middleware: []Middleware{trace("outer"), trace("inner")}})
+		_, err := client.Books().List()
+		assert.Nil(err)
+		assert.Equal([]string{"outer before", "inner before", "inner after", "outer after"}, calls)
+	})
+
+	t.Run("every attempt", func(t *testing.T) {
+		var attempts int32
+		count := func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&attempts, 1)
+				return next.Do(req)
+			})
+		}
+		atomic.StoreInt32(&fail, 2)
+		client := NewWithConfig(ClientConfig{
+			BaseURL:    server.URL,
+			Retry:      &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			Middleware: []Middleware{count},
+		})
+		_, err := client.Books().List()
+		assert.Nil(err)
+		assert.Equal(int32(3), atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("short circuit", func(t *testing.T) {
+		canned := func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{},
+					Body:       ioutil.NopCloser(strings.NewReader(`{"docs": [{"name": "Canned"}]}`)),
+					Request:    req,
+				}, nil
+			})
+		}
+		client := NewWithConfig(ClientConfig{BaseURL: "http://unreachable.invalid", Middleware: []Middleware{canned}})
+		books, err := client.Books().List()
+		assert.Nil(err)
+		assert.Equal("Canned", books[0].Name)
+	})
+
+	t.Run("headers", func(t *testing.T) {
+		client := NewWithConfig(ClientConfig{
+			BaseURL: server.URL,
+			ApiKey:  "secret",
+			Middleware: []Middleware{
+				HeaderMiddleware(http.Header{"X-Team": {"platform"}}),
+				HeaderFuncMiddleware(func(req *http.Request) http.Header {
+					id, _ := req.Context().Value(correlationKey{}).(string)
+					return http.Header{"X-Correlation-Id": {id}}
+				}),
+			},
+		})
+		ctx := context.WithValue(context.Background(), correlationKey{}, "abc-123")
+		_, err := client.Books().ListContext(ctx)
+		assert.Nil(err)
+
+		mu.Lock()
+		last := headers[len(headers)-1]
+		mu.Unlock()
+		assert.Equal("platform", last.Get("X-Team"))
+		assert.Equal("abc-123", last.Get("X-Correlation-Id"))
+		assert.Equal("Bearer secret", last.Get("Authorization"))
+	})
+
+	t.Run("logging", func(t *testing.T) {
+		var buf bytes.Buffer
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, Middleware: []Middleware{LoggingMiddleware(log.New(&buf, "", 0))}})
+		_, err := client.Books().List(WithLimit(1))
+		assert.Nil(err)
+		assert.Regexp(`^GET http://.+/book\?limit=1 200 \S+\n$`, buf.String())
+
+		buf.Reset()
+		client = NewWithConfig(ClientConfig{BaseURL: "http://127.0.0.1:1", Middleware: []Middleware{LoggingMiddleware(log.New(&buf, "", 0))}})
+		_, err = client.Books().List()
+		assert.NotNil(err)
+		assert.Contains(buf.String(), "GET http://127.0.0.1:1/book failed after")
+	})
+
+	t.Run("dump", func(t *testing.T) {
+		var buf bytes.Buffer
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "secret", Middleware: []Middleware{DumpMiddleware(&buf, true)}})
+		books, err := client.Books().List()
+		assert.Nil(err)
+		assert.Equal("The Hobbit", books[0].Name)
+
+		dump := buf.String()
+		assert.Contains(dump, "GET /book HTTP/1.1")
+		assert.Contains(dump, "Authorization: REDACTED")
+		assert.NotContains(dump, "secret")
+		assert.Contains(dump, "HTTP/1.1 200 OK")
+		assert.Contains(dump, `{"docs": [{"name": "The Hobbit"}]}`)
+	})
+}
@@ -1,69 +1,51 @@
 package sdk
 
 import (
-	"errors"
-	"fmt"
+	"context"
+	"encoding/json"
 )
 
-type moviesResponse struct {
-	Docs []Movie
-}
+type moviesResponse = listResponse[Movie]
 
 // Movie repesetns a single movie resource
 type Movie struct {
-	ID                         string `json:"_id,omitempty"`
-	Name                       string `json:"name,omitempty"`
-	RuntimeInMinutes           int
-	BudgetInMillions           int
-	BoxOfficeRevenueInMillions float32
-	AcademyAwardNominations    int
-	AcademyAwardWins           int
-	RottenTomatoesScore        float32
+	ID                         string  `json:"_id,omitempty"`
+	Name                       string  `json:"name,omitempty"`
+	RuntimeInMinutes           int     `json:"runtimeInMinutes"`
+	BudgetInMillions           int     `json:"budgetInMillions"`
+	BoxOfficeRevenueInMillions float32 `json:"boxOfficeRevenueInMillions"`
+	AcademyAwardNominations    int     `json:"academyAwardNominations"`
+	AcademyAwardWins           int     `json:"academyAwardWins"`
+	RottenTomatoesScore        float32 `json:"rottenTomatoesScore"`
+	// Extra holds the fields returned by the API which are not part of the model
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // MoviesClient provides methods for interacting with movie resources
 type MoviesClient struct {
-	c OneAPIClient
+	ResourceClient[Movie]
 }
 
-// List returns a list of all movies
-func (m MoviesClient) List(opts ...RequestOption) ([]Movie, error) {
-	resp := moviesResponse{}
-
-	opts = m.c.appendOptsToAuth(opts...)
-	err := m.c.doRequestInto("/movie", &resp, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return resp.Docs, nil
-}
-
-// Get returns a single movie by ID
-func (m MoviesClient) Get(id string, opts ...RequestOption) (Movie, error) {
-	path := fmt.Sprintf("/movie/%s", id)
-	resp := moviesResponse{}
-
-	opts = m.c.appendOptsToAuth(opts...)
-	err := m.c.doRequestInto(path, &resp, opts...)
-	if err != nil {
-		return Movie{}, err
-	}
-	return resp.Docs[0], err
+// Quotes returns the Collection of quotes of a single movie
+func (m MoviesClient) Quotes(id string) Collection[Quote] {
+	return Nested[Quote](m.ResourceClient, id, "quote")
 }
 
 // GetQuotes returns all quotes of a single movie
 func (m MoviesClient) GetQuotes(id string, opts ...RequestOption) ([]Quote, error) {
-	path := fmt.Sprintf("/movie/%s/quote", id)
-	resp := quoteResponse{}
+	return m.GetQuotesContext(context.Background(), id, opts...)
+}
 
-	opts = m.c.appendOptsToAuth(opts...)
-	err := m.c.doRequestInto(path, &resp, opts...)
+// GetQuotesContext is like GetQuotes but uses the provided context for the request
+func (m MoviesClient) GetQuotesContext(ctx context.Context, id string, opts ...RequestOption) ([]Quote, error) {
+	col := m.Quotes(id)
+	quotes, err := col.ListContext(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(resp.Docs) == 0 {
-		return nil, errors.New("no quotes available")
+	if len(quotes) == 0 {
+		return nil, noQuotesError(col.path)
 	}
-	return resp.Docs, err
+	return quotes, nil
 }
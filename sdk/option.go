@@ -1,13 +1,120 @@
 package sdk
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 )
 
+// ErrInvalidQuery indicates a RequestOption was provided invalid arguments
+var ErrInvalidQuery = errors.New("invalid query")
+
 // RequestOption can be provided to API Calls to modify the request
-type RequestOption func(*http.Request)
+type RequestOption func(*RequestBuilder)
+
+// FromRequestFunc adapts an option written for the previous RequestOption signature,
+// func(*http.Request), which modifies the request directly.
+// Query params it writes to the URL are sent before those added by other options
+func FromRequestFunc(fn func(*http.Request)) RequestOption {
+	return func(req *RequestBuilder) {
+		fn(req.Request)
+	}
+}
+
+// RequestBuilder is the request being modified by RequestOptions.
+// It embeds the underlying *http.Request, allowing options to modify headers directly.
+// Query params should be added using SetParam and AddFilter, which are encoded once all options are applied
+type RequestBuilder struct {
+	*http.Request
+	query       queryString
+	cache       cacheMode
+	concurrency int
+	expand      []string
+	err         error
+}
+
+// SetError causes the request to fail with err before being sent.
+// Only the first error set is kept
+func (r *RequestBuilder) SetError(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// Err returns the error set by an option, if any
+func (r *RequestBuilder) Err() error {
+	return r.err
+}
+
+// SetParam sets the query param key to val, replacing any previous value,
+// as used for pagination and sorting
+func (r *RequestBuilder) SetParam(key string, val string) {
+	r.query.set(key, val)
+}
+
+// AddFilter adds a condition on field to the query. Conditions accumulate,
+// so several conditions may be applied to the same field
+func (r *RequestBuilder) AddFilter(field string, op Operator, val string) {
+	r.query.add(field, op, val)
+}
+
+// applyOptions applies opts to a request which is never sent, such as to inspect the resulting query
+func applyOptions(opts ...RequestOption) *RequestBuilder {
+	req, _ := http.NewRequest(http.MethodGet, DEFAULT_BASE_URL, nil)
+	rb := &RequestBuilder{Request: req}
+	for _, opt := range opts {
+		opt(rb)
+	}
+	rb.encodeQuery()
+	return rb
+}
+
+// encodeQuery writes the query built by the applied options to the request URL,
+// after any query params written to the URL directly
+func (r *RequestBuilder) encodeQuery() {
+	query := r.query.encode()
+	switch {
+	case query == "":
+	case r.URL.RawQuery == "":
+		r.URL.RawQuery = query
+	default:
+		r.URL.RawQuery = r.URL.RawQuery + "&" + query
+	}
+}
+
+// Operator is a comparison operator used to filter resources by a numeric field
+type Operator string
+
+const (
+	// OpLt matches values less than the provided value
+	OpLt Operator = "<"
+	// OpLte matches values less than or equal to the provided value
+	OpLte Operator = "<="
+	// OpGt matches values greater than the provided value
+	OpGt Operator = ">"
+	// OpGte matches values greater than or equal to the provided value
+	OpGte Operator = ">="
+	// OpEq matches values equal to the provided value
+	OpEq Operator = "="
+	// OpNe matches values not equal to the provided value
+	OpNe Operator = "!="
+)
+
+// Valid reports whether o is a comparison operator supported by the API
+func (o Operator) Valid() bool {
+	switch o {
+	case OpLt, OpLte, OpGt, OpGte, OpEq, OpNe:
+		return true
+	}
+	return false
+}
+
+// reservedParams are query params used for pagination and sorting rather than filtering
+var reservedParams = map[string]bool{"limit": true, "page": true, "offset": true, "sort": true}
 
 // PaginationOptions provide paginiation options
 type PaginationOptions struct {
@@ -16,40 +123,103 @@ type PaginationOptions struct {
 	Offset int
 }
 
-func setQueryParam(req *http.Request, key string, val string) {
-	values := req.URL.Query()
-	values.Set(key, val)
-	req.URL.RawQuery = values.Encode()
+// queryParam is a single param or condition of a query string, such as limit=10 or race!=Orc
+type queryParam struct {
+	key string
+	op  Operator
+	val string
 }
 
-func setNegateQueryParam(req *http.Request, key string, negateVal string) {
-	query := req.URL.RawQuery
-	negate := fmt.Sprintf("%s!=%s", key, negateVal)
+// queryString is the ordered list of params and conditions applied by RequestOptions
+type queryString []queryParam
 
-	if len(query) == 0 {
-		query = fmt.Sprintf("%s", negate)
-	} else {
-		query = fmt.Sprintf("%s&%s", query, negate)
+// set replaces any params with key, keeping the position of the first
+func (q *queryString) set(key string, val string) {
+	params := (*q)[:0]
+	found := false
+	for _, p := range *q {
+		if p.key != key {
+			params = append(params, p)
+			continue
+		}
+		if !found {
+			params = append(params, queryParam{key: key, op: OpEq, val: val})
+			found = true
+		}
+	}
+	if !found {
+		params = append(params, queryParam{key: key, op: OpEq, val: val})
 	}
-	req.URL.RawQuery = query
+	*q = params
+}
 
+// add appends a condition, ignoring exact duplicates of existing conditions
+func (q *queryString) add(key string, op Operator, val string) {
+	p := queryParam{key: key, op: op, val: val}
+	for _, existing := range *q {
+		if existing == p {
+			return
+		}
+	}
+	*q = append(*q, p)
 }
-func setComparisonQueryParam(req *http.Request, key string, comp string, val int) {
-	query := req.URL.RawQuery
-	negate := fmt.Sprintf("%s%s%s", key, comp, fmt.Sprint(val))
 
-	if len(query) == 0 {
-		query = fmt.Sprintf("%s", negate)
-	} else {
-		query = fmt.Sprintf("%s&%s", query, negate)
+func (q queryString) encode() string {
+	parts := make([]string, 0, len(q))
+	for _, p := range q {
+		parts = append(parts, escapeQuery(p.key)+string(p.op)+escapeQuery(p.val))
 	}
-	req.URL.RawQuery = query
+	return strings.Join(parts, "&")
+}
+
+// queryUnescaper restores characters with meaning to the API which are safe to leave unescaped:
+// commas separating lists, slashes around regular expressions, and colons in sorts
+var queryUnescaper = strings.NewReplacer("%2C", ",", "%2F", "/", "%3A", ":")
+
+func escapeQuery(s string) string {
+	return queryUnescaper.Replace(url.QueryEscape(s))
+}
+
+// queryOperators are the operators which may separate a key and value in a query, longest first
+var queryOperators = []Operator{OpNe, OpLte, OpGte, OpLt, OpGt, OpEq}
 
+// parseQuery parses an encoded query string into its params and conditions
+func parseQuery(raw string) (queryString, error) {
+	var q queryString
+	for _, part := range strings.Split(raw, "&") {
+		if part == "" {
+			continue
+		}
+		i := strings.IndexAny(part, "!<>=")
+		if i <= 0 {
+			return nil, fmt.Errorf("%w: %q is not a condition", ErrInvalidQuery, part)
+		}
+		var op Operator
+		for _, o := range queryOperators {
+			if strings.HasPrefix(part[i:], string(o)) {
+				op = o
+				break
+			}
+		}
+		if op == "" {
+			return nil, fmt.Errorf("%w: %q is not a condition", ErrInvalidQuery, part)
+		}
+		key, err := url.QueryUnescape(part[:i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+		}
+		val, err := url.QueryUnescape(part[i+len(op):])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+		}
+		q = append(q, queryParam{key: key, op: op, val: val})
+	}
+	return q, nil
 }
 
 func withQuery(key string, val string) RequestOption {
-	return func(req *http.Request) {
-		setQueryParam(req, key, val)
+	return func(req *RequestBuilder) {
+		req.SetParam(key, val)
 	}
 }
 
@@ -70,89 +240,116 @@ func WithOffset(offset int) RequestOption {
 
 // WithPagination applies pagination options to the request
 func WithPagination(opts PaginationOptions) RequestOption {
-	return func(req *http.Request) {
+	return func(req *RequestBuilder) {
 		if opts.Offset > 0 {
-			setQueryParam(req, "offset", fmt.Sprint(opts.Offset))
+			req.SetParam("offset", fmt.Sprint(opts.Offset))
 		}
 		if opts.Limit > 0 {
-			setQueryParam(req, "limit", fmt.Sprint(opts.Limit))
+			req.SetParam("limit", fmt.Sprint(opts.Limit))
 		}
 		if opts.Page > 0 {
-			setQueryParam(req, "page", fmt.Sprint(opts.Page))
+			req.SetParam("page", fmt.Sprint(opts.Page))
 		}
 	}
 }
 
 // WithSort indicates a field and direction to sort returned resources.
-// field represents the field of an API resource, dir must be either "asc" or "dsc"
+// field represents the field of an API resource, dir must be either "asc" or "desc"
 // for example, WithSort("realm", "asc")
 func WithSort(field string, dir string) RequestOption {
-	return func(req *http.Request) {
+	return func(req *RequestBuilder) {
 		val := fmt.Sprintf("%s:%s", field, dir)
-		setQueryParam(req, "sort", val)
+		req.SetParam("sort", val)
 	}
 }
 
 // WithAPIKey causes an Authorization header to be applied to access authenticated resources
 //
 // Note that if an apiKey is provided to client creation, this is not needed and will be applied automatically.
-// This option is useful for setting a new apiKey or turning a read-only client into an authenticated client
+// This option is useful for setting a new apiKey or turning a read-only client into an authenticated client.
+// An empty apiKey removes any Authorization header rather than sending an empty token
 func WithAPIKey(apiKey string) RequestOption {
-	return func(req *http.Request) {
+	return func(req *RequestBuilder) {
+		if apiKey == "" {
+			req.Header.Del("Authorization")
+			return
+		}
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
 	}
 }
 
 // WithFilterMatch applies a filter to match resources with field = val
 func WithFilterMatch(field string, val string) RequestOption {
-	return func(req *http.Request) {
-		setQueryParam(req, field, val)
+	return func(req *RequestBuilder) {
+		req.AddFilter(field, OpEq, val)
 	}
 }
 
 // WithFilterNegate applies a negation filter to exlude resources with field = val
 func WithFilterNegate(field string, val string) RequestOption {
-	return func(req *http.Request) {
-		setNegateQueryParam(req, field, val)
+	return func(req *RequestBuilder) {
+		req.AddFilter(field, OpNe, val)
 	}
 }
 
 // WithFilterInclude applies a filter to include only resources with field matching one of vals
 func WithFilterInclude(field string, vals ...string) RequestOption {
-	return func(req *http.Request) {
+	return func(req *RequestBuilder) {
 		val := strings.Join(vals, ",")
-		setQueryParam(req, field, val)
+		req.AddFilter(field, OpEq, val)
 	}
 }
 
 // WithFilterInclude applies a filter to exlude all resources with field matching one of vals
 func WithFilterExclude(field string, vals ...string) RequestOption {
-	return func(req *http.Request) {
+	return func(req *RequestBuilder) {
 		val := strings.Join(vals, ",")
-		setNegateQueryParam(req, field, val)
+		req.AddFilter(field, OpNe, val)
 	}
 }
 
 // WithFilterRegex applies a filter to include only resources who's field's value matches the regex expression
 // Note that no validation is performed on the regex expression
 func WithRegexInclude(field string, expr string) RequestOption {
-	return func(req *http.Request) {
-		setQueryParam(req, field, expr)
+	return func(req *RequestBuilder) {
+		req.AddFilter(field, OpEq, expr)
 	}
 }
 
 // WithFilterInclude applies a filter to exlude all resources with field's value matching the regex expression
 func WithRegexExclude(field string, expr string) RequestOption {
-	return func(req *http.Request) {
-		setNegateQueryParam(req, field, expr)
+	return func(req *RequestBuilder) {
+		req.AddFilter(field, OpNe, expr)
 	}
 }
 
 // WithComparison applies a filter to match only resources with field's value matching the comparison operator
-// Acceptable comp arguments are "<", ">", and ">="
-// Note that the comparison operator is not valid
-func WithComparison(field string, comp string, val int) RequestOption {
-	return func(req *http.Request) {
-		setComparisonQueryParam(req, field, comp, val)
+// Acceptable operators are OpLt, OpLte, OpGt, OpGte, OpEq and OpNe, for example
+//
+//	WithComparison("rottenTomatoesScore", sdk.OpGte, 93.5)
+//
+// If the operator is not supported, the field is empty or reserved for pagination or sorting,
+// or the value is not a finite number, the request fails with ErrInvalidQuery before it is sent
+func WithComparison(field string, op Operator, val float64) RequestOption {
+	return func(req *RequestBuilder) {
+		if err := validateComparison(field, op, val); err != nil {
+			req.SetError(err)
+			return
+		}
+		req.AddFilter(field, op, strconv.FormatFloat(val, 'f', -1, 64))
+	}
+}
+
+func validateComparison(field string, op Operator, val float64) error {
+	switch {
+	case !op.Valid():
+		return fmt.Errorf("%w: unsupported comparison operator %q", ErrInvalidQuery, op)
+	case field == "":
+		return fmt.Errorf("%w: comparison requires a field", ErrInvalidQuery)
+	case reservedParams[field]:
+		return fmt.Errorf("%w: %q cannot be compared", ErrInvalidQuery, field)
+	case math.IsNaN(val) || math.IsInf(val, 0):
+		return fmt.Errorf("%w: %v is not a valid value to compare %q against", ErrInvalidQuery, val, field)
 	}
+	return nil
 }
@@ -0,0 +1,230 @@
+package sdk
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithComparison(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests int32
+	var got atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		got.Store(r.URL.RawQuery)
+		w.Write([]byte(`{"docs": []}`))
+	}))
+	defer server.Close()
+	client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key"})
+
+	valid := []struct {
+		op   Operator
+		val  float64
+		want string
+	}{
+		{OpLt, 180, "runtimeInMinutes<180"},
+		{OpLte, 180, "runtimeInMinutes<=180"},
+		{OpGt, 180, "runtimeInMinutes>180"},
+		{OpGte, 180, "runtimeInMinutes>=180"},
+		{OpEq, 180, "runtimeInMinutes=180"},
+		{OpNe, 180, "runtimeInMinutes!=180"},
+		{OpGte, 93.5, "runtimeInMinutes>=93.5"},
+		{OpLt, -0.25, "runtimeInMinutes<-0.25"},
+	}
+	for _, tt := range valid {
+		t.Run(tt.want, func(t *testing.T) {
+			_, err := client.Movies().List(WithComparison("runtimeInMinutes", tt.op, tt.val))
+			assert.Nil(err)
+			assert.Equal(tt.want, got.Load())
+		})
+	}
+
+	invalid := []struct {
+		name  string
+		field string
+		op    Operator
+		val   float64
+	}{
+		{"unsupported operator", "runtimeInMinutes", "~", 1},
+		{"empty operator", "runtimeInMinutes", "", 1},
+		{"operator with value", "runtimeInMinutes", ">5&limit", 1},
+		{"empty field", "", OpLt, 1},
+		{"reserved field", "limit", OpGt, 1},
+		{"nan", "runtimeInMinutes", OpLt, math.NaN()},
+		{"infinite", "runtimeInMinutes", OpLt, math.Inf(1)},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&requests, 0)
+			_, err := client.Movies().List(WithComparison(tt.field, tt.op, tt.val))
+			assert.ErrorIs(err, ErrInvalidQuery)
+			assert.ErrorIs(err, SDKError{Kind: ErrorKindRequest})
+			assert.Equal(int32(0), atomic.LoadInt32(&requests))
+		})
+	}
+
+	t.Run("query builder", func(t *testing.T) {
+		assert.Nil(Query(MovieRottenTomatoesScore.Gte(93.5)).Err())
+		assert.Equal("rottenTomatoesScore>=93.5", Query(MovieRottenTomatoesScore.Gte(93.5)).String())
+		assert.ErrorIs(Query(MovieBoxOfficeRevenueInMillions.Lt(math.NaN())).Err(), ErrInvalidQuery)
+	})
+}
+
+// encodeOptions returns the query string produced by applying opts to a request
+func encodeOptions(opts ...RequestOption) (string, error) {
+	rb := applyOptions(opts...)
+	return rb.URL.RawQuery, rb.Err()
+}
+
+func TestRequestOptions_combinations(t *testing.T) {
+	options := []struct {
+		name string
+		opt  RequestOption
+		want []string
+	}{
+		{"limit", WithLimit(10), []string{"limit=10"}},
+		{"page", WithPage(2), []string{"page=2"}},
+		{"offset", WithOffset(5), []string{"offset=5"}},
+		{"sort", WithSort("name", "asc"), []string{"sort=name:asc"}},
+		{"match", WithFilterMatch("name", "Frodo Baggins"), []string{"name=Frodo+Baggins"}},
+		{"negate", WithFilterNegate("name", "Gollum"), []string{"name!=Gollum"}},
+		{"include", WithFilterInclude("race", "Hobbit", "Elf"), []string{"race=Hobbit,Elf"}},
+		{"exclude", WithFilterExclude("race", "Orc", "Goblin"), []string{"race!=Orc,Goblin"}},
+		{"regex include", WithRegexInclude("name", "/foot/i"), []string{"name=/foot/i"}},
+		{"regex exclude", WithRegexExclude("name", "/sam/i"), []string{"name!=/sam/i"}},
+		{"lt", WithComparison("runtimeInMinutes", OpLt, 200), []string{"runtimeInMinutes<200"}},
+		{"gte", WithComparison("runtimeInMinutes", OpGte, 93.5), []string{"runtimeInMinutes>=93.5"}},
+		{"ne", WithComparison("academyAwardWins", OpNe, 0), []string{"academyAwardWins!=0"}},
+		{"pagination", WithPagination(PaginationOptions{Limit: 3, Page: 4, Offset: 1}), []string{"offset=1", "limit=3", "page=4"}},
+	}
+
+	t.Run("pairs", func(t *testing.T) {
+		for i, a := range options {
+			for j, b := range options {
+				if i == j {
+					continue
+				}
+				got, err := encodeOptions(a.opt, b.opt)
+				assert.Nil(t, err)
+
+				params := strings.Split(got, "&")
+				want := append(append([]string{}, a.want...), b.want...)
+				if overlaps(a.want, b.want) {
+					// pagination params are replaced by the later option
+					for _, w := range b.want {
+						assert.Contains(t, params, w, "%s then %s", a.name, b.name)
+					}
+					continue
+				}
+				assert.ElementsMatch(t, want, params, "%s then %s", a.name, b.name)
+			}
+		}
+	})
+
+	t.Run("all in any order", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(1))
+		for n := 0; n < 50; n++ {
+			perm := rnd.Perm(len(options))
+			opts := make([]RequestOption, 0, len(options))
+			var filters []string
+			for _, i := range perm {
+				opts = append(opts, options[i].opt)
+				for _, w := range options[i].want {
+					if !reservedParams[strings.SplitN(w, "=", 2)[0]] {
+						filters = append(filters, w)
+					}
+				}
+			}
+			got, err := encodeOptions(opts...)
+			assert.Nil(t, err)
+
+			// filters are kept in the order applied, alongside a single value of each reserved param
+			var gotFilters []string
+			reserved := map[string]int{}
+			for _, p := range strings.Split(got, "&") {
+				if key := strings.SplitN(p, "=", 2)[0]; reservedParams[key] {
+					reserved[key]++
+					continue
+				}
+				gotFilters = append(gotFilters, p)
+			}
+			assert.Equal(t, filters, gotFilters, got)
+			assert.Equal(t, map[string]int{"limit": 1, "page": 1, "offset": 1, "sort": 1}, reserved, got)
+		}
+	})
+
+	t.Run("multiple conditions per field", func(t *testing.T) {
+		got, err := encodeOptions(
+			WithComparison("runtimeInMinutes", OpGt, 100),
+			WithComparison("runtimeInMinutes", OpLt, 200),
+			WithFilterNegate("race", "Orc"),
+			WithFilterNegate("race", "Goblin"),
+			WithFilterNegate("race", "Orc"),
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, "runtimeInMinutes>100&runtimeInMinutes<200&race!=Orc&race!=Goblin", got)
+	})
+
+	t.Run("later pagination replaces earlier", func(t *testing.T) {
+		got, err := encodeOptions(WithLimit(10), WithFilterMatch("name", "Sam"), WithLimit(20), WithSort("name", "asc"), WithSort("race", "desc"))
+		assert.Nil(t, err)
+		assert.Equal(t, "limit=20&name=Sam&sort=race:desc", got)
+	})
+
+	t.Run("values are escaped", func(t *testing.T) {
+		got, err := encodeOptions(WithFilterNegate("name", "a&limit=1000"), WithFilterMatch("name", "x<5"))
+		assert.Nil(t, err)
+		assert.Equal(t, "name!=a%26limit%3D1000&name=x%3C5", got)
+	})
+
+	t.Run("persistent and per-call options", func(t *testing.T) {
+		var got atomic.Value
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got.Store(r.URL.RawQuery)
+			w.Write([]byte(`{"docs": []}`))
+		}))
+		defer server.Close()
+		client := NewWithConfig(ClientConfig{
+			BaseURL:           server.URL,
+			ApiKey:            "test-key",
+			PersistentOptions: []RequestOption{WithLimit(3), WithFilterNegate("race", "Orc")},
+		})
+
+		_, err := client.Characters().List(WithFilterExclude("race", "Goblin"), WithLimit(5))
+		assert.Nil(t, err)
+		assert.Equal(t, "limit=5&race!=Orc&race!=Goblin", got.Load())
+	})
+}
+
+func overlaps(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if strings.SplitN(x, "=", 2)[0] == strings.SplitN(y, "=", 2)[0] && reservedParams[strings.SplitN(x, "=", 2)[0]] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestFromRequestFunc(t *testing.T) {
+	assert := assert.New(t)
+	legacy := func(req *http.Request) {
+		req.Header.Set("X-Request-ID", "abc")
+		q := req.URL.Query()
+		q.Set("language", "sindarin")
+		req.URL.RawQuery = q.Encode()
+	}
+	rb := applyOptions(WithLimit(2), FromRequestFunc(legacy))
+	assert.Nil(rb.Err())
+	assert.Equal("abc", rb.Header.Get("X-Request-ID"))
+	assert.Equal("language=sindarin&limit=2", rb.URL.RawQuery)
+}
@@ -0,0 +1,188 @@
+package sdk
+
+//go:generate go run ./internal/fieldgen -output fields_gen.go -types Book,Movie,Character,Quote,Chapter book.go movie.go character.go quote.go chapter.go
+
+import "fmt"
+
+// Field is a field of an API resource which may be used to sort results
+type Field interface {
+	// Name returns the name of the field as used by the API
+	Name() string
+}
+
+// StringField is a text field of an API resource, such as CharacterRace.
+// Constants for the fields of each resource are provided by the SDK
+type StringField string
+
+// Name returns the name of the field as used by the API
+func (f StringField) Name() string {
+	return string(f)
+}
+
+// Eq matches resources where the field equals val
+func (f StringField) Eq(val string) Condition {
+	return Condition{opt: WithFilterMatch(string(f), val)}
+}
+
+// Ne matches resources where the field does not equal val
+func (f StringField) Ne(val string) Condition {
+	return Condition{opt: WithFilterNegate(string(f), val)}
+}
+
+// In matches resources where the field equals any of vals
+func (f StringField) In(vals ...string) Condition {
+	return Condition{opt: WithFilterInclude(string(f), vals...)}
+}
+
+// NotIn matches resources where the field equals none of vals
+func (f StringField) NotIn(vals ...string) Condition {
+	return Condition{opt: WithFilterExclude(string(f), vals...)}
+}
+
+// Matches matches resources where the field matches the regular expression, such as "/foot/i"
+func (f StringField) Matches(expr string) Condition {
+	return Condition{opt: WithRegexInclude(string(f), expr)}
+}
+
+// NotMatches matches resources where the field does not match the regular expression
+func (f StringField) NotMatches(expr string) Condition {
+	return Condition{opt: WithRegexExclude(string(f), expr)}
+}
+
+// NumberField is a numeric field of an API resource, such as MovieRuntimeInMinutes.
+// Constants for the fields of each resource are provided by the SDK
+type NumberField string
+
+// Name returns the name of the field as used by the API
+func (f NumberField) Name() string {
+	return string(f)
+}
+
+// Eq matches resources where the field equals val
+func (f NumberField) Eq(val float64) Condition {
+	return Condition{opt: WithComparison(string(f), OpEq, val)}
+}
+
+// Ne matches resources where the field does not equal val
+func (f NumberField) Ne(val float64) Condition {
+	return Condition{opt: WithComparison(string(f), OpNe, val)}
+}
+
+// Lt matches resources where the field is less than val
+func (f NumberField) Lt(val float64) Condition {
+	return Condition{opt: WithComparison(string(f), OpLt, val)}
+}
+
+// Lte matches resources where the field is less than or equal to val
+func (f NumberField) Lte(val float64) Condition {
+	return Condition{opt: WithComparison(string(f), OpLte, val)}
+}
+
+// Gt matches resources where the field is greater than val
+func (f NumberField) Gt(val float64) Condition {
+	return Condition{opt: WithComparison(string(f), OpGt, val)}
+}
+
+// Gte matches resources where the field is greater than or equal to val
+func (f NumberField) Gte(val float64) Condition {
+	return Condition{opt: WithComparison(string(f), OpGte, val)}
+}
+
+// Condition is a filter on a single field, created using the methods of StringField and NumberField.
+// A query with a zero Condition fails with ErrInvalidQuery
+type Condition struct {
+	opt RequestOption
+}
+
+// SortDirection is the order in which results are sorted
+type SortDirection string
+
+const (
+	// Asc sorts results in ascending order
+	Asc SortDirection = "asc"
+	// Desc sorts results in descending order
+	Desc SortDirection = "desc"
+)
+
+// QueryBuilder composes filters, sorting, and pagination into RequestOptions
+//
+//	opts := sdk.Query().
+//		Where(sdk.CharacterRace.In("Hobbit", "Elf")).
+//		And(sdk.CharacterRealm.Ne("")).
+//		SortBy(sdk.CharacterName, sdk.Asc).
+//		Build()
+//	characters, err := client.Characters().List(opts...)
+type QueryBuilder struct {
+	conditions []Condition
+	opts       []RequestOption
+}
+
+// Query creates a QueryBuilder, optionally starting with the provided conditions
+func Query(conditions ...Condition) *QueryBuilder {
+	return &QueryBuilder{conditions: conditions}
+}
+
+// Where adds a condition which resources must match
+func (q *QueryBuilder) Where(c Condition) *QueryBuilder {
+	q.conditions = append(q.conditions, c)
+	return q
+}
+
+// And adds a condition which resources must also match
+func (q *QueryBuilder) And(c Condition) *QueryBuilder {
+	return q.Where(c)
+}
+
+// SortBy sorts results by the field in the given direction
+func (q *QueryBuilder) SortBy(f Field, dir SortDirection) *QueryBuilder {
+	q.opts = append(q.opts, WithSort(f.Name(), string(dir)))
+	return q
+}
+
+// Limit limits the number of results returned
+func (q *QueryBuilder) Limit(limit int) *QueryBuilder {
+	q.opts = append(q.opts, WithLimit(limit))
+	return q
+}
+
+// Page requests a specific page of results
+func (q *QueryBuilder) Page(page int) *QueryBuilder {
+	q.opts = append(q.opts, WithPage(page))
+	return q
+}
+
+// Offset skips the given number of results
+func (q *QueryBuilder) Offset(offset int) *QueryBuilder {
+	q.opts = append(q.opts, WithOffset(offset))
+	return q
+}
+
+// Build returns the RequestOptions applying the query, to be passed to any resource method
+func (q *QueryBuilder) Build() []RequestOption {
+	opts := make([]RequestOption, 0, len(q.conditions)+len(q.opts))
+	for _, c := range q.conditions {
+		if c.opt == nil {
+			opts = append(opts, func(req *RequestBuilder) {
+				req.SetError(fmt.Errorf("%w: conditions must be created by the methods of a field", ErrInvalidQuery))
+			})
+			continue
+		}
+		opts = append(opts, c.opt)
+	}
+	return append(opts, q.opts...)
+}
+
+func (q *QueryBuilder) apply() *RequestBuilder {
+	return applyOptions(q.Build()...)
+}
+
+// Err returns the error caused by any invalid conditions of the query,
+// allowing a query to be validated before it is used
+func (q *QueryBuilder) Err() error {
+	return q.apply().Err()
+}
+
+// String returns the query string produced by the query, as it will be sent to the API
+func (q *QueryBuilder) String() string {
+	return q.apply().URL.RawQuery
+}
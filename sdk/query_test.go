@@ -0,0 +1,72 @@
+package sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *QueryBuilder
+		want  string
+	}{
+		{"empty", Query(), ""},
+		{"eq", Query().Where(CharacterName.Eq("Frodo Baggins")), "name=Frodo+Baggins"},
+		{"ne", Query().Where(CharacterName.Ne("Frodo")), "name!=Frodo"},
+		{"in", Query().Where(CharacterRace.In("Hobbit", "Elf")), "race=Hobbit,Elf"},
+		{"not in", Query().Where(CharacterRace.NotIn("Orc", "Goblin")), "race!=Orc,Goblin"},
+		{"matches", Query().Where(CharacterName.Matches("/foot/i")), "name=/foot/i"},
+		{"not matches", Query().Where(CharacterName.NotMatches("/foot/i")), "name!=/foot/i"},
+		{"lt", Query().Where(MovieRuntimeInMinutes.Lt(160)), "runtimeInMinutes<160"},
+		{"lte", Query().Where(MovieRuntimeInMinutes.Lte(160)), "runtimeInMinutes<=160"},
+		{"gt", Query().Where(MovieAcademyAwardWins.Gt(0)), "academyAwardWins>0"},
+		{"gte", Query().Where(MovieBudgetInMillions.Gte(180)), "budgetInMillions>=180"},
+		{"number eq", Query().Where(MovieAcademyAwardWins.Eq(11)), "academyAwardWins=11"},
+		{"number ne", Query().Where(MovieAcademyAwardWins.Ne(0)), "academyAwardWins!=0"},
+		{"sort", Query().SortBy(CharacterName, Asc), "sort=name:asc"},
+		{"pagination", Query().Limit(10).Page(2).Offset(5), "limit=10&page=2&offset=5"},
+		{
+			"combined",
+			Query(CharacterRace.In("Hobbit", "Elf")).And(CharacterGender.Ne("Female")).SortBy(CharacterName, Desc).Limit(5),
+			"race=Hobbit,Elf&gender!=Female&sort=name:desc&limit=5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.query.String())
+		})
+	}
+}
+
+func TestQueryBuilder_Build(t *testing.T) {
+	assert := assert.New(t)
+
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.RawQuery
+		w.Write([]byte(`{"docs": []}`))
+	}))
+	defer server.Close()
+	client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key"})
+
+	q := Query().Where(MovieRuntimeInMinutes.Gte(180)).SortBy(MovieName, Asc)
+	_, err := client.Movies().List(q.Build()...)
+	assert.Nil(err)
+	assert.Equal(q.String(), got)
+}
+
+func TestQueryBuilder_zeroCondition(t *testing.T) {
+	assert := assert.New(t)
+
+	q := Query().Where(Condition{}).And(CharacterRace.Eq("Hobbit"))
+	assert.ErrorIs(q.Err(), ErrInvalidQuery)
+	assert.Equal("race=Hobbit", q.String())
+
+	client := NewWithConfig(ClientConfig{BaseURL: "http://localhost:0", ApiKey: "test-key"})
+	_, err := client.Characters().List(q.Build()...)
+	assert.ErrorIs(err, ErrInvalidQuery)
+}
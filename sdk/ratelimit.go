@@ -0,0 +1,184 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket used to limit the rate of requests sent to the API.
+//
+// The One API allows 100 requests every 10 minutes per API key, which can be matched with
+//
+//	sdk.NewRateLimiter(100, 10*time.Minute)
+//
+// The limiter also adapts to the rate limit headers returned by the API,
+// pausing all requests until the quota resets once it is exhausted.
+// A RateLimiter is safe for concurrent use and may be shared between clients using the same API key.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	// tokens added per second
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	// no requests are permitted until this time
+	blockedUntil time.Time
+}
+
+// NewRateLimiter creates a RateLimiter permitting the given number of requests per interval.
+// Up to requests calls may be made in a burst before requests are spread over the interval.
+// At least one request is permitted, and an interval of zero or less is treated as one second.
+func NewRateLimiter(requests int, interval time.Duration) *RateLimiter {
+	if requests < 1 {
+		requests = 1
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return &RateLimiter{
+		rate:   float64(requests) / interval.Seconds(),
+		burst:  float64(requests),
+		tokens: float64(requests),
+		last:   time.Now(),
+	}
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	if elapsed > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+elapsed*l.rate)
+		l.last = now
+	}
+}
+
+// Wait blocks until a request is permitted or the context is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.refill(now)
+
+		var wait time.Duration
+		switch {
+		case now.Before(l.blockedUntil):
+			wait = l.blockedUntil.Sub(now)
+		case l.tokens >= 1:
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		default:
+			wait = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		}
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// observe adapts the bucket to the rate limit reported by a response
+func (l *RateLimiter) observe(resp *http.Response) {
+	if l == nil || resp == nil {
+		return
+	}
+	now := time.Now()
+	info := parseRateLimit(resp, now)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(now)
+
+	if info.Remaining >= 0 && float64(info.Remaining) < l.tokens {
+		l.tokens = float64(info.Remaining)
+	}
+
+	var until time.Time
+	if resp.StatusCode == http.StatusTooManyRequests {
+		l.tokens = 0
+		if info.RetryAfter > 0 {
+			until = now.Add(info.RetryAfter)
+		}
+	} else if info.Remaining == 0 {
+		until = info.Reset
+	}
+	if until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// RateLimitError is returned when the API rejects a request because the rate limit was exceeded.
+// It matches ErrRateLimited when used with errors.Is
+type RateLimitError struct {
+	StatusError
+
+	// RetryAfter is how long to wait before making another request, zero if unknown
+	RetryAfter time.Duration
+	// Limit is the number of requests permitted per window, -1 if unknown
+	Limit int
+	// Remaining is the number of requests remaining in the window, -1 if unknown
+	Remaining int
+	// Reset is when the rate limit window resets, zero if unknown
+	Reset time.Time
+}
+
+func (e RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limit exceeded, retry after %s", e.RetryAfter)
+	}
+	return "rate limit exceeded"
+}
+
+// parseRateLimit reads the rate limit headers of a response,
+// using -1 for any counts not provided
+func parseRateLimit(resp *http.Response, now time.Time) RateLimitError {
+	info := RateLimitError{Limit: -1, Remaining: -1}
+	h := resp.Header
+
+	if v, err := strconv.Atoi(h.Get("X-RateLimit-Limit")); err == nil {
+		info.Limit = v
+	}
+	if v, err := strconv.Atoi(h.Get("X-RateLimit-Remaining")); err == nil {
+		info.Remaining = v
+	}
+	if v, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		// the reset may be provided as either a unix timestamp
+		// or the number of seconds until the window resets
+		if v > 1e9 {
+			info.Reset = time.Unix(v, 0)
+		} else {
+			info.Reset = now.Add(time.Duration(v) * time.Second)
+		}
+	}
+
+	if v := h.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			info.RetryAfter = time.Duration(secs) * time.Second
+		} else if t, err := http.ParseTime(v); err == nil {
+			info.RetryAfter = t.Sub(now)
+		}
+	}
+	// only wait for the window to reset once the quota is exhausted
+	exhausted := resp.StatusCode == http.StatusTooManyRequests || info.Remaining == 0
+	if info.RetryAfter <= 0 && exhausted && info.Reset.After(now) {
+		info.RetryAfter = info.Reset.Sub(now)
+	}
+	if info.RetryAfter < 0 {
+		info.RetryAfter = 0
+	}
+	return info
+}
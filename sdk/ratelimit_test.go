@@ -0,0 +1,126 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewRateLimiter(2, 100*time.Millisecond)
+	ctx := context.Background()
+
+	start := time.Now()
+	assert.Nil(l.Wait(ctx))
+	assert.Nil(l.Wait(ctx))
+	assert.Less(time.Since(start), 25*time.Millisecond)
+
+	// the third request must wait for a token to refill
+	assert.Nil(l.Wait(ctx))
+	assert.GreaterOrEqual(time.Since(start), 40*time.Millisecond)
+
+	t.Run("context canceled", func(t *testing.T) {
+		l := NewRateLimiter(1, time.Hour)
+		assert.Nil(l.Wait(ctx))
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(l.Wait(ctx), context.DeadlineExceeded)
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		for _, interval := range []time.Duration{0, -time.Minute} {
+			l := NewRateLimiter(1, interval)
+			assert.Nil(l.Wait(ctx))
+
+			// the next token refills after a second rather than never or immediately
+			ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+			assert.ErrorIs(l.Wait(ctx), context.DeadlineExceeded)
+			cancel()
+
+			l.last = l.last.Add(-time.Second)
+			start := time.Now()
+			assert.Nil(l.Wait(context.Background()))
+			assert.Less(time.Since(start), 25*time.Millisecond)
+		}
+	})
+
+	t.Run("nil limiter", func(t *testing.T) {
+		var l *RateLimiter
+		assert.Nil(l.Wait(ctx))
+	})
+}
+
+func TestParseRateLimit(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Unix(1700000000, 0)
+
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	resp.Header.Set("X-RateLimit-Limit", "100")
+	resp.Header.Set("X-RateLimit-Remaining", "42")
+	resp.Header.Set("X-RateLimit-Reset", "1700000060")
+
+	info := parseRateLimit(resp, now)
+	assert.Equal(100, info.Limit)
+	assert.Equal(42, info.Remaining)
+	assert.Equal(now.Add(time.Minute), info.Reset)
+	assert.Equal(time.Duration(0), info.RetryAfter)
+
+	resp.StatusCode = http.StatusTooManyRequests
+	info = parseRateLimit(resp, now)
+	assert.Equal(time.Minute, info.RetryAfter)
+
+	resp.Header.Set("Retry-After", "30")
+	info = parseRateLimit(resp, now)
+	assert.Equal(30*time.Second, info.RetryAfter)
+
+	resp = &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	info = parseRateLimit(resp, now)
+	assert.Equal(RateLimitError{Limit: -1, Remaining: -1}, info)
+}
+
+func TestOneAPIClient_rateLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	var limited int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&limited) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"success": false, "message": "Too many requests, please try again later."}`))
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.Write([]byte(`{"docs": []}`))
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(100, time.Minute)
+	client := NewWithConfig(ClientConfig{BaseURL: server.URL, RateLimiter: limiter})
+
+	_, err := client.Books().List()
+	assert.Nil(err)
+
+	atomic.StoreInt32(&limited, 1)
+	_, err = client.Books().List()
+
+	var rateErr RateLimitError
+	assert.True(errors.As(err, &rateErr))
+	assert.Equal(time.Second, rateErr.RetryAfter)
+
+	// the limiter is paused until the retry after duration has passed
+	atomic.StoreInt32(&limited, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.Books().ListContext(ctx)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+}
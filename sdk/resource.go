@@ -0,0 +1,292 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// DefaultConcurrency is the number of requests made at once by GetMany unless set with WithConcurrency
+const DefaultConcurrency = 4
+
+// maxBatchSize is the number of ids requested in a single list call by GetMany,
+// keeping the query within the length accepted by the API
+const maxBatchSize = 100
+
+// batchResources are the resources which may be filtered by _id,
+// allowing GetMany to fetch many resources in a single list call
+var batchResources = map[string]bool{
+	"book":      true,
+	"movie":     true,
+	"character": true,
+	"quote":     true,
+	"chapter":   true,
+}
+
+// listResponse is the envelope in which the API returns all resources
+type listResponse[T any] struct {
+	paginatedResponse
+	Docs []T `json:"docs"`
+}
+
+// Collection provides methods for listing the resources of type T served by a single endpoint,
+// such as /character or /character/{id}/quote
+type Collection[T any] struct {
+	c    OneAPIClient
+	path string
+	// err is returned by all methods when the collection could not be created
+	err error
+}
+
+// List returns all resources in the collection
+func (col Collection[T]) List(opts ...RequestOption) ([]T, error) {
+	return col.ListContext(context.Background(), opts...)
+}
+
+// ListContext is like List but uses the provided context for the request
+func (col Collection[T]) ListContext(ctx context.Context, opts ...RequestOption) ([]T, error) {
+	page, err := col.ListPageContext(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// ListPage returns a single page of the collection along with the pagination details provided by the API
+func (col Collection[T]) ListPage(opts ...RequestOption) (Page[T], error) {
+	return col.ListPageContext(context.Background(), opts...)
+}
+
+// ListPageContext is like ListPage but uses the provided context for the request
+func (col Collection[T]) ListPageContext(ctx context.Context, opts ...RequestOption) (Page[T], error) {
+	ctx, span := col.c.startSpan(ctx, col.path, "list")
+	defer span.End()
+	if col.err != nil {
+		span.RecordError(col.err)
+		return Page[T]{}, col.err
+	}
+	resp := listResponse[T]{}
+
+	err := col.c.doRequestInto(ctx, col.path, &resp, opts...)
+	if err != nil {
+		return Page[T]{}, err
+	}
+	span.SetAttributes(
+		Attribute{"oneapi.page", resp.Page},
+		Attribute{"oneapi.result_count", len(resp.Docs)},
+		Attribute{"oneapi.total", resp.Total},
+	)
+	return newPage(resp.Docs, resp.paginatedResponse), nil
+}
+
+// Iter returns an Iterator which lazily walks every page of the collection
+func (col Collection[T]) Iter(opts ...RequestOption) *Iterator[T] {
+	return col.IterContext(context.Background(), opts...)
+}
+
+// IterContext is like Iter but uses the provided context for each page request
+func (col Collection[T]) IterContext(ctx context.Context, opts ...RequestOption) *Iterator[T] {
+	return newIterator(ctx, col.path, col.ListPageContext, opts...)
+}
+
+// Count returns the total number of resources in the collection matching any provided filters
+func (col Collection[T]) Count(opts ...RequestOption) (int, error) {
+	return col.CountContext(context.Background(), opts...)
+}
+
+// CountContext is like Count but uses the provided context for the request
+func (col Collection[T]) CountContext(ctx context.Context, opts ...RequestOption) (int, error) {
+	// only a single resource is needed to learn the total
+	opts = append(opts[:len(opts):len(opts)], WithLimit(1))
+	page, err := col.ListPageContext(ctx, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return page.Total, nil
+}
+
+// ResourceClient provides methods for interacting with a namespace of resources of type T,
+// such as /character. It may be used to access endpoints not yet provided by the SDK
+//
+//	type Race struct {
+//		ID   string `json:"_id"`
+//		Name string `json:"name"`
+//	}
+//	races := sdk.NewResourceClient[Race](client, "race")
+//	all, err := races.List()
+type ResourceClient[T any] struct {
+	Collection[T]
+	resource string
+}
+
+// NewResourceClient creates a ResourceClient for the resources of type T served at /{resource}
+func NewResourceClient[T any](c OneAPIClient, resource string) ResourceClient[T] {
+	resource = strings.Trim(resource, "/")
+	return ResourceClient[T]{
+		Collection: Collection[T]{c: c, path: "/" + resource},
+		resource:   resource,
+	}
+}
+
+// Get returns a single resource by ID
+func (r ResourceClient[T]) Get(id string, opts ...RequestOption) (T, error) {
+	return r.GetContext(context.Background(), id, opts...)
+}
+
+// GetContext is like Get but uses the provided context for the request
+func (r ResourceClient[T]) GetContext(ctx context.Context, id string, opts ...RequestOption) (T, error) {
+	var zero T
+	path := fmt.Sprintf("%s/%s", r.path, id)
+	ctx, span := r.c.startSpan(ctx, path, "get")
+	defer span.End()
+	if err := validateID(path, id); err != nil {
+		span.RecordError(err)
+		return zero, err
+	}
+
+	resp := listResponse[T]{}
+
+	err := r.c.doRequestInto(ctx, path, &resp, opts...)
+	if err != nil {
+		return zero, err
+	}
+	span.SetAttributes(Attribute{"oneapi.result_count", len(resp.Docs)})
+	if len(resp.Docs) == 0 {
+		err := notFoundError(path, r.resource, id)
+		span.RecordError(err)
+		return zero, err
+	}
+	return resp.Docs[0], nil
+}
+
+// Nested returns the Collection of resources of type S nested under the resource with the given id,
+// for example the quotes of a character
+//
+//	quotes := sdk.Nested[sdk.Quote](client.Characters().ResourceClient, id, "quote")
+func Nested[S any, T any](r ResourceClient[T], id string, resource string) Collection[S] {
+	path := fmt.Sprintf("%s/%s/%s", r.path, id, strings.Trim(resource, "/"))
+	return Collection[S]{
+		c:    r.c,
+		path: path,
+		err:  validateID(path, id),
+	}
+}
+
+// WithConcurrency sets the number of requests made at once by GetMany.
+// All requests still wait on the client's RateLimiter
+func WithConcurrency(n int) RequestOption {
+	return func(req *RequestBuilder) {
+		req.concurrency = n
+	}
+}
+
+// GetMany returns the resources with the given ids keyed by id, along with the error for each id which
+// could not be fetched. Duplicate ids are fetched once.
+//
+// Resources which may be filtered by _id are fetched using a single list call per batch of ids,
+// otherwise each id is fetched with Get. Up to DefaultConcurrency requests are made at once,
+// which may be changed with WithConcurrency
+func (r ResourceClient[T]) GetMany(ids []string, opts ...RequestOption) (map[string]T, map[string]error) {
+	return r.GetManyContext(context.Background(), ids, opts...)
+}
+
+// GetManyContext is like GetMany but uses the provided context for each request
+func (r ResourceClient[T]) GetManyContext(ctx context.Context, ids []string, opts ...RequestOption) (map[string]T, map[string]error) {
+	var (
+		mu      sync.Mutex
+		results = map[string]T{}
+		errs    = map[string]error{}
+		pending []string
+		seen    = map[string]bool{}
+	)
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if err := validateID(fmt.Sprintf("%s/%s", r.path, id), id); err != nil {
+			errs[id] = err
+			continue
+		}
+		pending = append(pending, id)
+	}
+
+	concurrency := applyOptions(opts...).concurrency
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+
+	var (
+		tasks []func()
+		index = fieldsOf(reflect.TypeOf((*T)(nil)).Elem())["_id"]
+	)
+	if batchResources[r.resource] && index != nil {
+		for start := 0; start < len(pending); start += maxBatchSize {
+			end := start + maxBatchSize
+			if end > len(pending) {
+				end = len(pending)
+			}
+			batch := pending[start:end]
+			tasks = append(tasks, func() {
+				found, err := r.getBatch(ctx, batch, index, opts...)
+				mu.Lock()
+				defer mu.Unlock()
+				for _, id := range batch {
+					switch item, ok := found[id]; {
+					case err != nil:
+						errs[id] = err
+					case ok:
+						results[id] = item
+					default:
+						errs[id] = notFoundError(fmt.Sprintf("%s/%s", r.path, id), r.resource, id)
+					}
+				}
+			})
+		}
+	} else {
+		for _, id := range pending {
+			id := id
+			tasks = append(tasks, func() {
+				item, err := r.GetContext(ctx, id, opts...)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errs[id] = err
+					return
+				}
+				results[id] = item
+			})
+		}
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(task func()) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			task()
+		}(task)
+	}
+	wg.Wait()
+	return results, errs
+}
+
+// getBatch lists the resources with the given ids, keyed by the id at index
+func (r ResourceClient[T]) getBatch(ctx context.Context, ids []string, index []int, opts ...RequestOption) (map[string]T, error) {
+	opts = append(opts[:len(opts):len(opts)], WithFilterInclude("_id", ids...), WithLimit(len(ids)), WithPage(1))
+	page, err := r.ListPageContext(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]T, len(page.Items))
+	for _, item := range page.Items {
+		id := valueOf(reflect.Indirect(reflect.ValueOf(item)), index)
+		found[id.str] = item
+	}
+	return found, nil
+}
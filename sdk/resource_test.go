@@ -0,0 +1,235 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testID = "5cd99d4bde30eff6ebccfe9e"
+
+// resourceCalls exercises every method of every resource client which decodes docs
+func resourceCalls(client OneAPIClient, id string) map[string]func() error {
+	return map[string]func() error{
+		"books list":           func() error { _, err := client.Books().List(); return err },
+		"books list page":      func() error { _, err := client.Books().ListPage(); return err },
+		"books get":            func() error { _, err := client.Books().Get(id); return err },
+		"books chapters":       func() error { _, err := client.Books().GetChapters(id); return err },
+		"movies list":          func() error { _, err := client.Movies().List(); return err },
+		"movies list page":     func() error { _, err := client.Movies().ListPage(); return err },
+		"movies get":           func() error { _, err := client.Movies().Get(id); return err },
+		"movies quotes":        func() error { _, err := client.Movies().GetQuotes(id); return err },
+		"characters list":      func() error { _, err := client.Characters().List(); return err },
+		"characters list page": func() error { _, err := client.Characters().ListPage(); return err },
+		"characters get":       func() error { _, err := client.Characters().Get(id); return err },
+		"characters quotes":    func() error { _, err := client.Characters().GetQuotes(id); return err },
+		"quotes list":          func() error { _, err := client.Quotes().List(); return err },
+		"quotes list page":     func() error { _, err := client.Quotes().ListPage(); return err },
+		"quotes get":           func() error { _, err := client.Quotes().Get(id); return err },
+		"chapters list":        func() error { _, err := client.Chapters().List(); return err },
+		"chapters list page":   func() error { _, err := client.Chapters().ListPage(); return err },
+		"chapters get":         func() error { _, err := client.Chapters().Get(id); return err },
+		"quotes iter":          func() error { _, err := client.Quotes().Iter().All(); return err },
+		"characters iter":      func() error { _, err := client.Characters().Iter().All(); return err },
+		"books iter":           func() error { _, err := client.Books().Iter().All(); return err },
+		"movies iter":          func() error { _, err := client.Movies().Iter().All(); return err },
+		"chapters iter":        func() error { _, err := client.Chapters().Iter().All(); return err },
+	}
+}
+
+func TestResourceClients_emptyDocs(t *testing.T) {
+	bodies := map[string]string{
+		"empty docs":   `{"docs": [], "total": 0, "limit": 1000, "offset": 0, "page": 1, "pages": 1}`,
+		"null docs":    `{"docs": null}`,
+		"missing docs": `{}`,
+		"empty body":   ``,
+		"null body":    `null`,
+	}
+
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			body := body
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key"})
+			for call, f := range resourceCalls(client, testID) {
+				assert.NotPanics(t, func() { f() }, call)
+			}
+		})
+	}
+}
+
+func TestResourceClients_Get(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"docs": []}`))
+	}))
+	defer server.Close()
+	client := NewWithConfig(ClientConfig{BaseURL: server.URL, ApiKey: "test-key"})
+
+	gets := map[string]func(id string) error{
+		"book":      func(id string) error { _, err := client.Books().Get(id); return err },
+		"movie":     func(id string) error { _, err := client.Movies().Get(id); return err },
+		"character": func(id string) error { _, err := client.Characters().Get(id); return err },
+		"quote":     func(id string) error { _, err := client.Quotes().Get(id); return err },
+		"chapter":   func(id string) error { _, err := client.Chapters().Get(id); return err },
+	}
+
+	for resource, get := range gets {
+		t.Run(resource, func(t *testing.T) {
+			err := get(testID)
+			assert.ErrorIs(err, ErrNotFound)
+
+			var notFound NotFoundError
+			assert.True(errors.As(err, &notFound))
+			assert.Equal(NotFoundError{Resource: resource, ID: testID}, notFound)
+
+			atomic.StoreInt32(&requests, 0)
+			for _, id := range []string{"", "123", "5cd99d4bde30eff6ebccfe9", "5cd99d4bde30eff6ebccfe9z", "../../book", testID + "0"} {
+				err := get(id)
+				assert.ErrorIs(err, ErrInvalidID)
+				assert.ErrorIs(err, SDKError{Kind: ErrorKindRequest})
+			}
+			assert.Equal(int32(0), atomic.LoadInt32(&requests))
+		})
+	}
+
+	getQuotes := map[string]func(id string, opts ...RequestOption) ([]Quote, error){
+		"movie":     client.Movies().GetQuotes,
+		"character": client.Characters().GetQuotes,
+	}
+	for resource, get := range getQuotes {
+		_, err := get(testID)
+		assert.ErrorIs(err, ErrNotFound, resource)
+		assert.ErrorIs(err, SDKError{Kind: ErrorKindAPI, Endpoint: "/" + resource + "/" + testID + "/quote"}, resource)
+	}
+}
+
+func TestResourceClient(t *testing.T) {
+	assert := assert.New(t)
+
+	type race struct {
+		ID   string `json:"_id"`
+		Name string `json:"name"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/race":
+			assert.Equal("1", r.URL.Query().Get("limit"))
+			w.Write([]byte(`{"docs": [{"_id": "1", "name": "Hobbit"}], "total": 12, "limit": 1, "page": 1, "pages": 12}`))
+		case "/race/" + testID:
+			w.Write([]byte(`{"docs": [{"_id": "` + testID + `", "name": "Elf"}]}`))
+		case "/race/" + testID + "/character":
+			w.Write([]byte(`{"docs": [{"_id": "1", "name": "Legolas"}], "total": 1}`))
+		}
+	}))
+	defer server.Close()
+	client := NewWithConfig(ClientConfig{BaseURL: server.URL})
+
+	races := NewResourceClient[race](client, "/race/")
+
+	count, err := races.Count()
+	assert.Nil(err)
+	assert.Equal(12, count)
+
+	elf, err := races.Get(testID)
+	assert.Nil(err)
+	assert.Equal(race{ID: testID, Name: "Elf"}, elf)
+
+	elves, err := Nested[Character](races, testID, "character").List()
+	assert.Nil(err)
+	assert.Equal([]Character{{ID: "1", Name: "Legolas"}}, elves)
+
+	_, err = Nested[Character](races, "bad", "character").List()
+	assert.ErrorIs(err, ErrInvalidID)
+}
+
+func TestResourceClient_GetMany(t *testing.T) {
+	fake := newTestFake()
+	const (
+		gandalf = "5cd99d4bde30eff6ebccfc15"
+		missing = "5cd99d4bde30eff6ebccffff"
+	)
+
+	var (
+		requests  int32
+		inFlight  int32
+		maxFlight int32
+	)
+	counting := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&requests, 1)
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxFlight)
+				if n <= max || atomic.CompareAndSwapInt32(&maxFlight, max, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			return next.Do(req)
+		})
+	}
+	client := NewWithConfig(ClientConfig{
+		Client:     &http.Client{Transport: fake},
+		BaseURL:    "http://fake.the-one-api.dev/v2",
+		ApiKey:     "fake",
+		Middleware: []Middleware{counting},
+	})
+
+	t.Run("batched", func(t *testing.T) {
+		assert := assert.New(t)
+		atomic.StoreInt32(&requests, 0)
+		characters, errs := client.Characters().GetMany([]string{testID, gandalf, testID, missing, "frodo"})
+		assert.Equal(int32(1), atomic.LoadInt32(&requests))
+		assert.Len(characters, 2)
+		assert.Equal("Frodo Baggins", characters[testID].Name)
+		assert.Equal("Gandalf", characters[gandalf].Name)
+		assert.Len(errs, 2)
+		assert.ErrorIs(errs[missing], ErrNotFound)
+		assert.ErrorIs(errs["frodo"], ErrInvalidID)
+	})
+
+	t.Run("individually", func(t *testing.T) {
+		assert := assert.New(t)
+		ids := make([]string, 10)
+		items := make([]Character, len(ids))
+		for i := range ids {
+			ids[i] = fmt.Sprintf("5cd99d4bde30eff6ebcc%04x", i)
+			items[i] = Character{ID: ids[i], Name: fmt.Sprint(i)}
+		}
+		SetFakeResource(fake, "race", items)
+		atomic.StoreInt32(&requests, 0)
+		atomic.StoreInt32(&maxFlight, 0)
+
+		races, errs := NewResourceClient[Character](client, "race").GetMany(append(ids, missing), WithConcurrency(3))
+		assert.Equal(int32(11), atomic.LoadInt32(&requests))
+		assert.LessOrEqual(atomic.LoadInt32(&maxFlight), int32(3))
+		assert.Len(races, 10)
+		assert.Equal("4", races[ids[4]].Name)
+		assert.Len(errs, 1)
+		assert.ErrorIs(errs[missing], ErrNotFound)
+	})
+
+	t.Run("failed batch", func(t *testing.T) {
+		assert := assert.New(t)
+		_, errs := client.Movies().GetMany([]string{testID, gandalf})
+		assert.Len(errs, 2)
+		assert.ErrorIs(errs[testID], ErrNotFound)
+		assert.ErrorIs(errs[gandalf], ErrNotFound)
+	})
+}
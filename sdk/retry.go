@@ -0,0 +1,180 @@
+package sdk
+
+import (
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures how requests that fail due to transient errors are retried.
+//
+// Only idempotent requests are made by the SDK, so any request may be safely retried.
+// Zero values for MaxAttempts, BaseDelay, MaxDelay and RetryableStatusCodes
+// fall back to those of DefaultRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the initial request
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, which doubles with each following attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts
+	MaxDelay time.Duration
+	// Jitter is the fraction of each delay, between 0 and 1, which is randomized
+	// to avoid many clients retrying in lockstep
+	Jitter float64
+	// RetryableStatusCodes are the HTTP status codes that cause a request to be retried
+	RetryableStatusCodes []int
+	// RetryableError reports whether a failed request should be retried.
+	// If nil, all errors are retried unless the request's context is done
+	RetryableError func(err error) bool
+}
+
+// DefaultRetryPolicy returns a policy making up to 3 attempts, retrying network errors,
+// rate limited requests and server errors
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil {
+		return 1
+	}
+	if p.MaxAttempts < 1 {
+		return DefaultRetryPolicy().MaxAttempts
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		if p.RetryableError != nil {
+			return p.RetryableError(err)
+		}
+		return true
+	}
+
+	codes := p.RetryableStatusCodes
+	if len(codes) == 0 {
+		codes = DefaultRetryPolicy().RetryableStatusCodes
+	}
+	for _, code := range codes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay to wait after the given attempt
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	defaults := DefaultRetryPolicy()
+	base, max := p.BaseDelay, p.MaxDelay
+	if base <= 0 {
+		base = defaults.BaseDelay
+	}
+	if max <= 0 {
+		max = defaults.MaxDelay
+	}
+
+	delay := float64(base) * math.Pow(2, float64(attempt-1))
+	if delay > float64(max) {
+		delay = float64(max)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay -= delay * jitter * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// delay returns how long to wait before retrying after the given attempt.
+// If the API asks for a longer wait than MaxDelay, the request is not retried.
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) (time.Duration, bool) {
+	delay := p.backoff(attempt)
+	if resp == nil {
+		return delay, true
+	}
+	retryAfter := parseRateLimit(resp, time.Now()).RetryAfter
+	if retryAfter <= delay {
+		return delay, true
+	}
+	max := p.MaxDelay
+	if max <= 0 {
+		max = DefaultRetryPolicy().MaxDelay
+	}
+	return retryAfter, retryAfter <= max
+}
+
+// do sends the request, retrying according to the client's RetryPolicy.
+// It returns the final response along with the number of attempts made
+func (c OneAPIClient) do(req *http.Request) (*http.Response, int, error) {
+	ctx := req.Context()
+	doer := chain(c.client, c.middleware)
+	attempt := 0
+	for {
+		// the breaker is checked first so requests fail fast while it is open,
+		// without waiting for or using up the rate limit
+		ticket, err := c.breaker.allow()
+		if err != nil {
+			return nil, attempt, err
+		}
+
+		if err := c.limiter.Wait(ctx); err != nil {
+			// release the ticket, which is ignored as the request was never sent
+			c.breaker.done(ticket, req, nil, err)
+			return nil, attempt, err
+		}
+
+		attempt++
+		resp, err := doer.Do(req.Clone(ctx))
+		c.breaker.done(ticket, req, resp, err)
+		c.limiter.observe(resp)
+		if attempt >= c.retry.maxAttempts() || !c.retry.retryable(req, resp, err) {
+			return resp, attempt, err
+		}
+
+		delay, ok := c.retry.delay(attempt, resp)
+		if !ok {
+			return resp, attempt, err
+		}
+
+		args := []interface{}{"endpoint", req.URL.Path, "attempt", attempt, "delay", delay}
+		if err != nil {
+			args = append(args, "error", err)
+		} else {
+			args = append(args, "status", resp.StatusCode)
+		}
+		c.log().Warn("retrying request", args...)
+
+		if resp != nil {
+			// drain the body so the connection may be reused
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempt, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
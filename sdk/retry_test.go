@@ -0,0 +1,117 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	assert := assert.New(t)
+	p := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	assert.Equal(100*time.Millisecond, p.backoff(1))
+	assert.Equal(200*time.Millisecond, p.backoff(2))
+	assert.Equal(400*time.Millisecond, p.backoff(3))
+	assert.Equal(time.Second, p.backoff(5))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := p.backoff(2)
+		assert.LessOrEqual(d, 200*time.Millisecond)
+		assert.GreaterOrEqual(d, 100*time.Millisecond)
+	}
+}
+
+func TestOneAPIClient_retry(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		switch r.URL.Path {
+		case "/flaky":
+			if n < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case "/down":
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html>bad gateway</html>"))
+			return
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success": false, "message": "not found"}`))
+			return
+		}
+		w.Write([]byte(`{"docs": [{"_id": "123"}]}`))
+	}))
+	defer server.Close()
+
+	policy := &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	client := NewWithConfig(ClientConfig{BaseURL: server.URL, Retry: policy})
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		resp := booksResponse{}
+		err := client.doRequestInto(context.Background(), "/flaky", &resp)
+		assert.Nil(err)
+		assert.Equal("123", resp.Docs[0].ID)
+		assert.Equal(int32(3), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("exhausts attempts", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		err := client.doRequestInto(context.Background(), "/down", &booksResponse{})
+		var sdkErr SDKError
+		assert.True(errors.As(err, &sdkErr))
+		assert.Equal(3, sdkErr.Attempts)
+		assert.Equal(int32(3), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("does not retry other status codes", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		err := client.doRequestInto(context.Background(), "/missing", &booksResponse{})
+		var sdkErr SDKError
+		assert.True(errors.As(err, &sdkErr))
+		assert.Equal(1, sdkErr.Attempts)
+		assert.Equal(int32(1), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("without policy", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL})
+		err := client.doRequestInto(context.Background(), "/down", &booksResponse{})
+		assert.NotNil(err)
+		assert.Equal(int32(1), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("retries network errors", func(t *testing.T) {
+		unreachable := httptest.NewServer(http.NotFoundHandler())
+		unreachable.Close()
+
+		client := NewWithConfig(ClientConfig{BaseURL: unreachable.URL, Retry: policy})
+		err := client.doRequestInto(context.Background(), "/book", &booksResponse{})
+		var sdkErr SDKError
+		assert.True(errors.As(err, &sdkErr))
+		assert.Equal(3, sdkErr.Attempts)
+	})
+
+	t.Run("stops when context is done", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		slow := &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+		client := NewWithConfig(ClientConfig{BaseURL: server.URL, Retry: slow})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := client.doRequestInto(ctx, "/down", &booksResponse{})
+		assert.ErrorIs(err, context.DeadlineExceeded)
+		assert.Equal(int32(1), atomic.LoadInt32(&requests))
+	})
+}
@@ -0,0 +1,146 @@
+package sdk
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Names of the metrics recorded by the client
+const (
+	// MetricRequests counts the requests sent to the API
+	MetricRequests = "oneapi.client.requests"
+	// MetricErrors counts the requests which failed, including those failing before being sent such as for a missing API key
+	MetricErrors = "oneapi.client.errors"
+	// MetricDuration records the duration of each request sent to the API in seconds, including retries
+	MetricDuration = "oneapi.client.duration"
+	// MetricResponseBytes counts the bytes of the response bodies received from the API
+	MetricResponseBytes = "oneapi.client.response.bytes"
+)
+
+// Attribute is a key-value pair describing a span or measurement
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Tracer starts spans around each call to a resource, such as quotes.list or characters.get.
+// It may be implemented by an adapter for a tracing library such as OpenTelemetry
+type Tracer interface {
+	// Start starts a span with the given name, returning a context containing it
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single traced call
+type Span interface {
+	// SetAttributes adds attributes describing the call
+	SetAttributes(attrs ...Attribute)
+	// RecordError records that the call failed
+	RecordError(err error)
+	// End completes the span
+	End()
+}
+
+// Meter creates the instruments used to record metrics about requests.
+// It may be implemented by an adapter for a metrics library such as OpenTelemetry
+type Meter interface {
+	// Int64Counter returns the counter with the given name
+	Int64Counter(name string) Int64Counter
+	// Float64Histogram returns the histogram with the given name
+	Float64Histogram(name string) Float64Histogram
+}
+
+// Int64Counter records a sum of values
+type Int64Counter interface {
+	Add(ctx context.Context, n int64, attrs ...Attribute)
+}
+
+// Float64Histogram records a distribution of values
+type Float64Histogram interface {
+	Record(ctx context.Context, v float64, attrs ...Attribute)
+}
+
+type nopTracer struct{}
+
+func (nopTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, nopSpan{}
+}
+
+type nopSpan struct{}
+
+func (nopSpan) SetAttributes(attrs ...Attribute) {}
+func (nopSpan) RecordError(err error)            {}
+func (nopSpan) End()                             {}
+
+type nopMeter struct{}
+
+func (nopMeter) Int64Counter(name string) Int64Counter         { return nopInstrument{} }
+func (nopMeter) Float64Histogram(name string) Float64Histogram { return nopInstrument{} }
+
+type nopInstrument struct{}
+
+func (nopInstrument) Add(ctx context.Context, n int64, attrs ...Attribute)      {}
+func (nopInstrument) Record(ctx context.Context, v float64, attrs ...Attribute) {}
+
+// tracer returns the client's tracer, which does nothing if none was configured
+func (c OneAPIClient) tracer() Tracer {
+	if c.tracing == nil {
+		return nopTracer{}
+	}
+	return c.tracing
+}
+
+// meter returns the client's meter, which does nothing if none was configured
+func (c OneAPIClient) meter() Meter {
+	if c.metrics == nil {
+		return nopMeter{}
+	}
+	return c.metrics
+}
+
+type spanKey struct{}
+
+// startSpan starts the span for an operation on the resources at path, such as characters.quotes.list
+func (c OneAPIClient) startSpan(ctx context.Context, path string, operation string) (context.Context, Span) {
+	var names []string
+	for i, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		// skip the ids of nested resources
+		if i%2 == 0 {
+			names = append(names, pluralize(segment))
+		}
+	}
+	ctx, span := c.tracer().Start(ctx, strings.Join(append(names, operation), "."))
+	span.SetAttributes(Attribute{"oneapi.endpoint", path})
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// spanFromContext returns the span of the resource call being made with ctx
+func spanFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		return span
+	}
+	return nopSpan{}
+}
+
+func pluralize(resource string) string {
+	if strings.HasSuffix(resource, "s") {
+		return resource
+	}
+	return resource + "s"
+}
+
+// recordRequest records the metrics for a call to the endpoint at path.
+// Requests which were never sent, such as those served from the cache, are only counted if they fail
+func (c OneAPIClient) recordRequest(ctx context.Context, path string, status int, attempts int, latency time.Duration, bytes int, kind ErrorKind) {
+	meter := c.meter()
+	resource := Attribute{"oneapi.resource", resourceOf(path)}
+	if attempts > 0 {
+		attrs := []Attribute{resource, {"http.status_code", status}}
+		meter.Int64Counter(MetricRequests).Add(ctx, 1, attrs...)
+		meter.Float64Histogram(MetricDuration).Record(ctx, latency.Seconds(), attrs...)
+		meter.Int64Counter(MetricResponseBytes).Add(ctx, int64(bytes), attrs...)
+	}
+	if kind != ErrorKindUnknown {
+		meter.Int64Counter(MetricErrors).Add(ctx, 1, resource, Attribute{"oneapi.error_kind", kind.String()})
+	}
+}
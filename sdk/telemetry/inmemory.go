@@ -0,0 +1,173 @@
+// Package telemetry provides adapters for the tracing and metrics hooks of the SDK.
+//
+// InMemoryExporter records spans and metrics in memory, allowing the telemetry of
+// code using the SDK to be inspected in tests. The adapter for OpenTelemetry is in the
+// telemetry/otel package, a separate module so the SDK takes no dependency on OpenTelemetry.
+package telemetry
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/treethought/cam-sweeney-sdk/sdk"
+)
+
+// SpanData is a span recorded by an InMemoryExporter
+type SpanData struct {
+	Name       string
+	Attributes map[string]interface{}
+	Errors     []error
+	Start      time.Time
+	End        time.Time
+	// Parent is the name of the span which was active when this span started, if any
+	Parent string
+}
+
+// Measurement is a single value recorded by an instrument
+type Measurement struct {
+	Value      float64
+	Attributes map[string]interface{}
+}
+
+// InMemoryExporter is an sdk.Tracer and sdk.Meter recording all spans and measurements in memory.
+// It is safe for concurrent use
+//
+//	exporter := telemetry.NewInMemoryExporter()
+//	client := sdk.NewWithConfig(sdk.ClientConfig{Tracer: exporter, Meter: exporter})
+type InMemoryExporter struct {
+	mu           sync.Mutex
+	spans        []SpanData
+	measurements map[string][]Measurement
+}
+
+// NewInMemoryExporter creates an empty InMemoryExporter
+func NewInMemoryExporter() *InMemoryExporter {
+	return &InMemoryExporter{measurements: map[string][]Measurement{}}
+}
+
+type spanKey struct{}
+
+// Start starts a span which is recorded once it ends
+func (e *InMemoryExporter) Start(ctx context.Context, name string) (context.Context, sdk.Span) {
+	s := &span{exporter: e, data: SpanData{Name: name, Attributes: map[string]interface{}{}, Start: time.Now()}}
+	if parent, ok := ctx.Value(spanKey{}).(*span); ok {
+		s.data.Parent = parent.data.Name
+	}
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// Spans returns the spans which have ended, in the order they ended
+func (e *InMemoryExporter) Spans() []SpanData {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]SpanData(nil), e.spans...)
+}
+
+// Int64Counter returns the counter with the given name
+func (e *InMemoryExporter) Int64Counter(name string) sdk.Int64Counter {
+	return instrument{exporter: e, name: name}
+}
+
+// Float64Histogram returns the histogram with the given name
+func (e *InMemoryExporter) Float64Histogram(name string) sdk.Float64Histogram {
+	return instrument{exporter: e, name: name}
+}
+
+// Measurements returns the values recorded by the named instrument
+func (e *InMemoryExporter) Measurements(name string) []Measurement {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]Measurement(nil), e.measurements[name]...)
+}
+
+// Sum returns the sum of the values recorded by the named instrument
+func (e *InMemoryExporter) Sum(name string) float64 {
+	var sum float64
+	for _, m := range e.Measurements(name) {
+		sum += m.Value
+	}
+	return sum
+}
+
+// Names returns the names of all instruments which have recorded a value
+func (e *InMemoryExporter) Names() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	names := make([]string, 0, len(e.measurements))
+	for name := range e.measurements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Reset discards all recorded spans and measurements
+func (e *InMemoryExporter) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = nil
+	e.measurements = map[string][]Measurement{}
+}
+
+type span struct {
+	exporter *InMemoryExporter
+	mu       sync.Mutex
+	data     SpanData
+	ended    bool
+}
+
+func (s *span) SetAttributes(attrs ...sdk.Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, attr := range attrs {
+		s.data.Attributes[attr.Key] = attr.Value
+	}
+}
+
+func (s *span) RecordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Errors = append(s.data.Errors, err)
+}
+
+func (s *span) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	data := s.data
+	data.Attributes = make(map[string]interface{}, len(s.data.Attributes))
+	for k, v := range s.data.Attributes {
+		data.Attributes[k] = v
+	}
+	data.Errors = append([]error(nil), s.data.Errors...)
+	s.mu.Unlock()
+
+	s.exporter.mu.Lock()
+	defer s.exporter.mu.Unlock()
+	s.exporter.spans = append(s.exporter.spans, data)
+}
+
+type instrument struct {
+	exporter *InMemoryExporter
+	name     string
+}
+
+func (i instrument) Add(ctx context.Context, n int64, attrs ...sdk.Attribute) {
+	i.Record(ctx, float64(n), attrs...)
+}
+
+func (i instrument) Record(ctx context.Context, v float64, attrs ...sdk.Attribute) {
+	m := Measurement{Value: v, Attributes: map[string]interface{}{}}
+	for _, attr := range attrs {
+		m.Attributes[attr.Key] = attr.Value
+	}
+	i.exporter.mu.Lock()
+	defer i.exporter.mu.Unlock()
+	i.exporter.measurements[i.name] = append(i.exporter.measurements[i.name], m)
+}
@@ -0,0 +1,104 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/treethought/cam-sweeney-sdk/sdk"
+)
+
+const testID = "5cd99d4bde30eff6ebccfe9e"
+
+func TestInMemoryExporter(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/quote":
+			w.Write([]byte(`{"docs": [{"dialog": "Deagol!"}, {"dialog": "Give us that"}], "total": 4, "page": 2, "pages": 2}`))
+		case "/character/" + testID + "/quote":
+			w.Write([]byte(`{"docs": []}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	exporter := NewInMemoryExporter()
+	client := sdk.NewWithConfig(sdk.ClientConfig{BaseURL: server.URL, ApiKey: "test-key", Tracer: exporter, Meter: exporter})
+
+	quotes, err := client.Quotes().List(sdk.WithLimit(2), sdk.WithPage(2))
+	assert.Nil(err)
+	assert.Len(quotes, 2)
+	_, err = client.Characters().Quotes(testID).List()
+	assert.Nil(err)
+	_, err = client.Movies().Get(testID)
+	assert.ErrorIs(err, sdk.ErrServer)
+	_, err = client.Books().Get("invalid")
+	assert.ErrorIs(err, sdk.ErrInvalidID)
+
+	spans := exporter.Spans()
+	if assert.Len(spans, 4) {
+		assert.Equal("quotes.list", spans[0].Name)
+		assert.Equal(map[string]interface{}{
+			"oneapi.endpoint":     "/quote",
+			"http.status_code":    http.StatusOK,
+			"oneapi.attempts":     1,
+			"oneapi.page":         2,
+			"oneapi.result_count": 2,
+			"oneapi.total":        4,
+		}, spans[0].Attributes)
+		assert.Empty(spans[0].Errors)
+		assert.False(spans[0].End.Before(spans[0].Start))
+
+		assert.Equal("characters.quotes.list", spans[1].Name)
+		assert.Equal(0, spans[1].Attributes["oneapi.result_count"])
+
+		assert.Equal("movies.get", spans[2].Name)
+		assert.Equal("/movie/"+testID, spans[2].Attributes["oneapi.endpoint"])
+		assert.Equal(http.StatusInternalServerError, spans[2].Attributes["http.status_code"])
+		if assert.Len(spans[2].Errors, 1) {
+			assert.ErrorIs(spans[2].Errors[0], sdk.ErrServer)
+		}
+
+		assert.Equal("books.get", spans[3].Name)
+		assert.Len(spans[3].Errors, 1)
+	}
+
+	assert.Equal([]string{sdk.MetricDuration, sdk.MetricErrors, sdk.MetricRequests, sdk.MetricResponseBytes}, exporter.Names())
+	assert.Equal(float64(3), exporter.Sum(sdk.MetricRequests))
+	// invalid IDs fail before a request is made
+	assert.Equal(float64(1), exporter.Sum(sdk.MetricErrors))
+	assert.Len(exporter.Measurements(sdk.MetricDuration), 3)
+
+	requests := exporter.Measurements(sdk.MetricRequests)
+	assert.Equal(map[string]interface{}{"oneapi.resource": "quote", "http.status_code": http.StatusOK}, requests[0].Attributes)
+	assert.Equal(map[string]interface{}{"oneapi.resource": "movie", "http.status_code": http.StatusInternalServerError}, requests[2].Attributes)
+
+	errs := exporter.Measurements(sdk.MetricErrors)
+	assert.Equal(map[string]interface{}{"oneapi.resource": "movie", "oneapi.error_kind": "HTTP Error"}, errs[0].Attributes)
+
+	bytes := exporter.Measurements(sdk.MetricResponseBytes)
+	assert.Equal(float64(len(`{"docs": [{"dialog": "Deagol!"}, {"dialog": "Give us that"}], "total": 4, "page": 2, "pages": 2}`)), bytes[0].Value)
+
+	exporter.Reset()
+	assert.Empty(exporter.Spans())
+	assert.Empty(exporter.Names())
+}
+
+func TestInMemoryExporter_parent(t *testing.T) {
+	exporter := NewInMemoryExporter()
+	ctx, parent := exporter.Start(context.Background(), "sync")
+	_, child := exporter.Start(ctx, "quotes.list")
+	child.End()
+	parent.End()
+	parent.End()
+
+	spans := exporter.Spans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "sync", spans[0].Parent)
+	assert.Equal(t, "", spans[1].Parent)
+}
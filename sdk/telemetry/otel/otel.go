@@ -0,0 +1,148 @@
+// Package otel provides an adapter exporting the tracing and metrics of the SDK to OpenTelemetry.
+//
+// It is a separate module, so the SDK itself takes no dependency on OpenTelemetry.
+//
+//	client := sdk.NewWithConfig(sdk.ClientConfig{
+//		Tracer: otel.NewTracer(otelapi.Tracer("oneapi")),
+//		Meter:  otel.NewMeter(otelapi.Meter("oneapi")),
+//	})
+package otel
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/treethought/cam-sweeney-sdk/sdk"
+	otelapi "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer is an sdk.Tracer starting spans with an OpenTelemetry tracer
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer creates a Tracer starting spans with tracer
+func NewTracer(tracer trace.Tracer) Tracer {
+	return Tracer{tracer: tracer}
+}
+
+// Start starts a span as a child of any span in ctx
+func (t Tracer) Start(ctx context.Context, name string) (context.Context, sdk.Span) {
+	ctx, s := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, span{s}
+}
+
+type span struct {
+	span trace.Span
+}
+
+func (s span) SetAttributes(attrs ...sdk.Attribute) {
+	s.span.SetAttributes(attributes(attrs)...)
+}
+
+// RecordError records err as an event of the span and sets its status to error
+func (s span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s span) End() {
+	s.span.End()
+}
+
+// Meter is an sdk.Meter recording metrics with an OpenTelemetry meter.
+// Instruments are created once for each name and reused. It is safe for concurrent use
+type Meter struct {
+	meter      metric.Meter
+	mu         sync.Mutex
+	counters   map[string]metric.Int64Counter
+	histograms map[string]metric.Float64Histogram
+}
+
+// NewMeter creates a Meter recording metrics with meter
+func NewMeter(meter metric.Meter) *Meter {
+	return &Meter{
+		meter:      meter,
+		counters:   map[string]metric.Int64Counter{},
+		histograms: map[string]metric.Float64Histogram{},
+	}
+}
+
+// Int64Counter returns the counter with the given name. If it cannot be created, the error
+// is passed to the OpenTelemetry error handler and the values added to it are dropped
+func (m *Meter) Int64Counter(name string) sdk.Int64Counter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.counters[name]
+	if !ok {
+		var err error
+		if c, err = m.meter.Int64Counter(name); err != nil {
+			otelapi.Handle(err)
+			c = noop.Int64Counter{}
+		}
+		m.counters[name] = c
+	}
+	return counter{c}
+}
+
+// Float64Histogram returns the histogram with the given name. If it cannot be created, the error
+// is passed to the OpenTelemetry error handler and the values recorded by it are dropped
+func (m *Meter) Float64Histogram(name string) sdk.Float64Histogram {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.histograms[name]
+	if !ok {
+		var err error
+		if h, err = m.meter.Float64Histogram(name); err != nil {
+			otelapi.Handle(err)
+			h = noop.Float64Histogram{}
+		}
+		m.histograms[name] = h
+	}
+	return histogram{h}
+}
+
+type counter struct {
+	counter metric.Int64Counter
+}
+
+func (c counter) Add(ctx context.Context, n int64, attrs ...sdk.Attribute) {
+	c.counter.Add(ctx, n, metric.WithAttributes(attributes(attrs)...))
+}
+
+type histogram struct {
+	histogram metric.Float64Histogram
+}
+
+func (h histogram) Record(ctx context.Context, v float64, attrs ...sdk.Attribute) {
+	h.histogram.Record(ctx, v, metric.WithAttributes(attributes(attrs)...))
+}
+
+// attributes converts attrs to OpenTelemetry attributes, formatting values of
+// types without an attribute type as strings
+func attributes(attrs []sdk.Attribute) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		switch v := attr.Value.(type) {
+		case string:
+			kvs = append(kvs, attribute.String(attr.Key, v))
+		case bool:
+			kvs = append(kvs, attribute.Bool(attr.Key, v))
+		case int:
+			kvs = append(kvs, attribute.Int(attr.Key, v))
+		case int64:
+			kvs = append(kvs, attribute.Int64(attr.Key, v))
+		case float64:
+			kvs = append(kvs, attribute.Float64(attr.Key, v))
+		default:
+			kvs = append(kvs, attribute.String(attr.Key, fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
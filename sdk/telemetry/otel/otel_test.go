@@ -0,0 +1,89 @@
+package otel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/treethought/cam-sweeney-sdk/sdk"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestAdapter(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/quote" {
+			w.Write([]byte(`{"docs": [{"dialog": "Deagol!"}, {"dialog": "Give us that"}], "total": 4, "page": 2, "pages": 2}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	client := sdk.NewWithConfig(sdk.ClientConfig{
+		BaseURL: server.URL,
+		ApiKey:  "test-key",
+		Tracer:  NewTracer(tracerProvider.Tracer("test")),
+		Meter:   NewMeter(meterProvider.Meter("test")),
+	})
+	_, err := client.Quotes().List(sdk.WithLimit(2), sdk.WithPage(2))
+	assert.Nil(err)
+	_, err = client.Movies().Get("5cd95395de30eff6ebccde5c")
+	assert.ErrorIs(err, sdk.ErrServer)
+
+	ended := spans.Ended()
+	if assert.Len(ended, 2) {
+		assert.Equal("quotes.list", ended[0].Name())
+		assert.Contains(ended[0].Attributes(), attribute.Int("oneapi.page", 2))
+		assert.Contains(ended[0].Attributes(), attribute.String("oneapi.endpoint", "/quote"))
+		assert.Equal(codes.Unset, ended[0].Status().Code)
+		assert.Equal("movies.get", ended[1].Name())
+		assert.Equal(codes.Error, ended[1].Status().Code)
+	}
+
+	var rm metricdata.ResourceMetrics
+	assert.Nil(reader.Collect(context.Background(), &rm))
+	sums := map[string]int64{}
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, point := range sum.DataPoints {
+					sums[m.Name] += point.Value
+				}
+			}
+		}
+	}
+	assert.Equal(int64(2), sums[sdk.MetricRequests])
+	assert.Equal(int64(1), sums[sdk.MetricErrors])
+}
+
+func TestAttributes(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("a", "b"),
+		attribute.Bool("c", true),
+		attribute.Int("d", 1),
+		attribute.Int64("e", 2),
+		attribute.Float64("f", 0.5),
+		attribute.String("g", "[h]"),
+	}, attributes([]sdk.Attribute{
+		{Key: "a", Value: "b"},
+		{Key: "c", Value: true},
+		{Key: "d", Value: 1},
+		{Key: "e", Value: int64(2)},
+		{Key: "f", Value: 0.5},
+		{Key: "g", Value: []string{"h"}},
+	}))
+}
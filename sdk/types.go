@@ -1,13 +1,48 @@
 package sdk
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type paginatedResponse struct {
-	Total  int
-	Limit  int
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Page   int `json:"page"`
+	Pages  int `json:"pages"`
+}
+
+// Page represents a single page of resources along with the pagination details provided by the API
+type Page[T any] struct {
+	// Items are the resources contained in this page
+	Items []T
+	// Total is the total number of resources across all pages
+	Total int
+	// Limit is the maximum number of resources in a page
+	Limit int
+	// Offset is the number of resources skipped before this page
 	Offset int
-	Page   int
-	Pages  int
+	// Page is the number of this page, starting at 1
+	Page int
+	// Pages is the total number of pages
+	Pages int
+}
+
+func newPage[T any](docs []T, meta paginatedResponse) Page[T] {
+	return Page[T]{
+		Items:  docs,
+		Total:  meta.Total,
+		Limit:  meta.Limit,
+		Offset: meta.Offset,
+		Page:   meta.Page,
+		Pages:  meta.Pages,
+	}
+}
+
+// HasNext reports whether there are more pages after this one
+func (p Page[T]) HasNext() bool {
+	return p.Page < p.Pages
 }
 
 // APIError represents an error message provided by the API
@@ -16,11 +51,63 @@ type APIError struct {
 	Message string
 }
 
+// ErrorKind categorizes an SDKError by the stage of the request which failed
+type ErrorKind int
+
+const (
+	// ErrorKindUnknown is the zero value of ErrorKind
+	ErrorKindUnknown ErrorKind = iota
+	// ErrorKindRequest indicates the request could not be built, such as when provided an invalid option
+	ErrorKindRequest
+	// ErrorKindHTTP indicates the request failed to complete, or the API responded with a non-2xx status code
+	ErrorKindHTTP
+	// ErrorKindRead indicates the response body could not be read
+	ErrorKindRead
+	// ErrorKindAPI indicates the API responded with an error message
+	ErrorKindAPI
+	// ErrorKindDeserialization indicates the response could not be decoded into the resource
+	ErrorKindDeserialization
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindRequest:
+		return "Request Error"
+	case ErrorKindHTTP:
+		return "HTTP Error"
+	case ErrorKindRead:
+		return "Error reading response"
+	case ErrorKindAPI:
+		return "API Error"
+	case ErrorKindDeserialization:
+		return "Deserialization Error"
+	}
+	return "Unknown Error"
+}
+
 // SDKError represents an error when interacting with the API or SDK and provided details of any underlying APIError
+//
+// The underlying error may be retrieved with errors.As, for example
+//
+//	var apiErr sdk.APIError
+//	if errors.As(err, &apiErr) {
+//		fmt.Println(apiErr.Message)
+//	}
 type SDKError struct {
-	message  string
-	endpoint string
-	err      error
+	// Kind indicates which stage of the request failed
+	Kind ErrorKind
+	// Method is the HTTP method of the request
+	Method string
+	// Endpoint is the path of the request
+	Endpoint string
+	// StatusCode is the HTTP status code of the response, or zero if no response was received
+	StatusCode int
+	// Err is the underlying error, such as an APIError, StatusError or net.Error
+	Err error
+
+	// Attempts is the number of times the request was attempted
+	// including any retries
+	Attempts int
 }
 
 func (e APIError) Error() string {
@@ -28,5 +115,31 @@ func (e APIError) Error() string {
 }
 
 func (e SDKError) Error() string {
-	return fmt.Sprintf("%s %s: %v", e.message, e.endpoint, e.err)
+	if e.Attempts > 1 {
+		return fmt.Sprintf("%s %s (after %d attempts): %v", e.Kind, e.Endpoint, e.Attempts, e.Err)
+	}
+	return fmt.Sprintf("%s %s: %v", e.Kind, e.Endpoint, e.Err)
+}
+
+// Unwrap returns the underlying error, allowing errors.Is and errors.As
+// to inspect errors such as context.Canceled or APIError
+func (e SDKError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is an SDKError matching all of e's non-zero fields.
+// This allows matching errors by kind or endpoint regardless of other details
+//
+//	errors.Is(err, sdk.SDKError{Kind: sdk.ErrorKindAPI})
+func (e SDKError) Is(target error) bool {
+	t, ok := target.(SDKError)
+	if !ok {
+		return false
+	}
+	return (t.Kind == ErrorKindUnknown || t.Kind == e.Kind) &&
+		(t.Method == "" || t.Method == e.Method) &&
+		(t.Endpoint == "" || t.Endpoint == e.Endpoint) &&
+		(t.StatusCode == 0 || t.StatusCode == e.StatusCode) &&
+		(t.Attempts == 0 || t.Attempts == e.Attempts) &&
+		(t.Err == nil || errors.Is(e.Err, t.Err))
 }